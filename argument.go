@@ -0,0 +1,13 @@
+package queryf
+
+import "encoding/json"
+
+// String returns the SQL literal of the argument.
+func (a *Argument) String() string {
+	return a.Format()
+}
+
+// MarshalJSON returns the SQL literal of the argument as a JSON string.
+func (a *Argument) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Format())
+}
@@ -0,0 +1,29 @@
+package queryf
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func (suite *QueryfTestSuite) TestArgument() {
+	arg := NewArgument("it's")
+	suite.Equal(`'it''s'`, arg.Format())
+	suite.Equal(`'it''s'`, arg.String())
+	suite.Equal(`arg='it''s'`, fmt.Sprintf("arg=%s", arg))
+
+	b, err := json.Marshal(map[string]any{"args": []*Argument{NewArgument(1), NewArgument(nil), arg}})
+	suite.Nil(err)
+	suite.Equal(`{"args":["1","NULL","'it''s'"]}`, string(b))
+}
+
+func (suite *QueryfTestSuite) TestRedactedArgument() {
+	arg := New(WithRedactor(func(_ int, arg any) bool {
+		_, ok := arg.(password)
+		return ok
+	})).NewArgument(password("secret"))
+	suite.Equal(`'[REDACTED]'`, fmt.Sprint(arg))
+
+	b, err := json.Marshal(arg)
+	suite.Nil(err)
+	suite.Equal(`"'[REDACTED]'"`, string(b))
+}
@@ -0,0 +1,61 @@
+package queryf
+
+import "strings"
+
+// Statement is a query with its arguments, one of the statements of a batch.
+type Statement struct {
+	Query string
+	Args  []any
+}
+
+// FormatBatch formats each statement of a batch, e.g. the queries of a
+// transaction or of a pgx.Batch.
+//
+// Example:
+//
+//	queries := queryf.FormatBatch([]queryf.Statement{
+//		{Query: "UPDATE accounts SET balance = balance - $1 WHERE id = $2", Args: []any{100, 1}},
+//		{Query: "UPDATE accounts SET balance = balance + $1 WHERE id = $2", Args: []any{100, 2}},
+//	})
+//	fmt.Println(queries[1])
+//	// Output: UPDATE accounts SET balance = balance + 100 WHERE id = 2
+func FormatBatch(stmts []Statement) []string {
+	return Default().FormatBatch(stmts)
+}
+
+// FormatBatch formats each statement of a batch with the Formatter
+// configuration. See the package level FormatBatch for details.
+func (f *Formatter) FormatBatch(stmts []Statement) []string {
+	queries := make([]string, len(stmts))
+	for i, stmt := range stmts {
+		queries[i] = f.Format(stmt.Query, stmt.Args...)
+	}
+	return queries
+}
+
+// FormatScript formats each statement of a batch like FormatBatch and joins
+// them with ";\n", so the whole batch can be read, or pasted into psql, at
+// once.
+//
+// Example:
+//
+//	fmt.Println(queryf.FormatScript([]queryf.Statement{
+//		{Query: "UPDATE accounts SET balance = balance - $1 WHERE id = $2", Args: []any{100, 1}},
+//		{Query: "UPDATE accounts SET balance = balance + $1 WHERE id = $2;", Args: []any{100, 2}},
+//	}))
+//	// Output:
+//	// UPDATE accounts SET balance = balance - 100 WHERE id = 1;
+//	// UPDATE accounts SET balance = balance + 100 WHERE id = 2
+func FormatScript(stmts []Statement) string {
+	return Default().FormatScript(stmts)
+}
+
+// FormatScript formats each statement of a batch with the Formatter
+// configuration and joins them. See the package level FormatScript for details.
+func (f *Formatter) FormatScript(stmts []Statement) string {
+	queries := f.FormatBatch(stmts)
+	for i, query := range queries {
+		queries[i] = strings.TrimRight(strings.TrimSpace(query), ";")
+	}
+	return strings.Join(queries, ";\n")
+}
@@ -0,0 +1,28 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestFormatBatch() {
+	stmts := []Statement{
+		{Query: `UPDATE accounts SET balance = balance - $1 WHERE id = $2`, Args: []any{100, 1}},
+		{Query: `UPDATE accounts SET balance = balance + $1 WHERE id = $2;`, Args: []any{100, 2}},
+		{Query: `INSERT INTO transfers (note) VALUES ($1)`, Args: []any{nil}},
+	}
+	suite.Equal([]string{
+		`UPDATE accounts SET balance = balance - 100 WHERE id = 1`,
+		`UPDATE accounts SET balance = balance + 100 WHERE id = 2;`,
+		`INSERT INTO transfers (note) VALUES (NULL)`,
+	}, FormatBatch(stmts))
+	suite.Empty(FormatBatch(nil))
+
+	f := New(WithNullLiteral("null"))
+	suite.Equal(`INSERT INTO transfers (note) VALUES (null)`, f.FormatBatch(stmts)[2])
+}
+
+func (suite *QueryfTestSuite) TestFormatScript() {
+	stmts := []Statement{
+		{Query: `UPDATE accounts SET balance = balance - $1 WHERE id = $2`, Args: []any{100, 1}},
+		{Query: "UPDATE accounts SET balance = balance + $1 WHERE id = $2; \n", Args: []any{100, 2}},
+	}
+	suite.Equal("UPDATE accounts SET balance = balance - 100 WHERE id = 1;\n"+
+		"UPDATE accounts SET balance = balance + 100 WHERE id = 2", FormatScript(stmts))
+	suite.Equal("", FormatScript(nil))
+}
@@ -0,0 +1,71 @@
+package queryf
+
+import (
+	"strings"
+	"testing"
+)
+
+type benchmarkNode struct {
+	Name     string
+	Value    int
+	Children []benchmarkNode
+}
+
+// benchmarkTree returns a tree of the given depth where every node has two
+// children.
+func benchmarkTree(depth int) benchmarkNode {
+	node := benchmarkNode{Name: "node", Value: depth}
+	if depth > 0 {
+		node.Children = []benchmarkNode{benchmarkTree(depth - 1), benchmarkTree(depth - 1)}
+	}
+	return node
+}
+
+func BenchmarkFormatLargeQuery(b *testing.B) {
+	query := `SELECT * FROM users WHERE id = $1 AND ` + strings.Repeat(`name <> 'it''s $2' AND /* $3 */ `, 1000) + `active = $2`
+	b.ReportAllocs()
+	b.SetBytes(int64(len(query)))
+	for i := 0; i < b.N; i++ {
+		Format(query, 1, true)
+	}
+}
+
+func BenchmarkFormatManyArgs(b *testing.B) {
+	query, args := benchmarkQuery(1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Format(query, args...)
+	}
+}
+
+func BenchmarkFormatDeepStruct(b *testing.B) {
+	tree := benchmarkTree(8)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Format(`SELECT $1`, tree)
+	}
+}
+
+func BenchmarkFormatBigArray(b *testing.B) {
+	ids := make([]int64, 10000)
+	names := make([]string, 10000)
+	for i := range ids {
+		ids[i] = int64(i)
+		names[i] = "name"
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Format(`SELECT * FROM users WHERE id = ANY($1) OR name = ANY($2)`, ids, names)
+	}
+}
+
+func (suite *QueryfTestSuite) TestFuzzSafeFormat() {
+	query, err := FuzzSafeFormat(`SELECT $1`, 1)
+	suite.NoError(err)
+	suite.Equal(`SELECT 1`, query)
+
+	f := New(WithRedactor(func(int, any) bool { panic("boom") }))
+	_, err = f.FuzzSafeFormat(`SELECT $1`, 1)
+	suite.ErrorIs(err, ErrPanic)
+	suite.EqualError(err, `queryf: formatting panicked: boom`)
+}
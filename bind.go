@@ -0,0 +1,147 @@
+package queryf
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// BindNamed converts the :name and @name placeholders of the query to $1, $2,
+// etc. and returns the arguments in the same order, so the query can be
+// executed. The values come from a map with string keys or from a struct,
+// whose fields are named by their db tag or by their lowercased name, like
+// sqlx does. The fields of nested structs are named after the struct field,
+// e.g. :address.city, and so are those of embedded structs with a db tag.
+//
+// Example:
+//
+//	query, args, _ := queryf.BindNamed("SELECT * FROM users WHERE id = :id AND name = :name", user)
+//	fmt.Println(query, args)
+//	// Output: SELECT * FROM users WHERE id = $1 AND name = $2 [1 John]
+func BindNamed(query string, arg any) (string, []any, error) {
+	return Default().BindNamed(query, arg)
+}
+
+// BindNamed converts the named placeholders of the query to the Formatter
+// placeholder style. See the package level BindNamed for details.
+func (f *Formatter) BindNamed(query string, arg any) (string, []any, error) {
+	values, err := f.namedValues(arg)
+	if err != nil {
+		return "", nil, err
+	}
+	style := f.style()
+	if style == Named {
+		style = Dollar
+	}
+
+	var args []any
+	var missing error
+	indices := map[string]int{}
+	query = substitute(query, syntax{style: Named, backslash: f.syntax().backslash}, func(p placeholder) (string, bool) {
+		value, ok := values[p.name]
+		if !ok {
+			if missing == nil {
+				missing = fmt.Errorf("%w: %s", ErrMissingArgument, query[p.start:p.end])
+			}
+			return "", false
+		}
+		index, ok := indices[p.name]
+		if !ok || style == Question {
+			args = append(args, value)
+			index = len(args)
+			indices[p.name] = index
+		}
+		return placeholderText(style, index), true
+	})
+	if missing != nil {
+		return "", nil, missing
+	}
+	return query, args, nil
+}
+
+// namedValues returns the values of a map with string keys or of a struct by
+// name.
+func (f *Formatter) namedValues(arg any) (map[string]any, error) {
+	if m, ok := arg.(map[string]any); ok {
+		return m, nil
+	}
+	rv := reflect.ValueOf(arg)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	values := map[string]any{}
+	switch {
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		iter := rv.MapRange()
+		for iter.Next() {
+			values[iter.Key().String()] = iter.Value().Interface()
+		}
+	case rv.Kind() == reflect.Struct:
+		f.structValues(rv, "", values, nil)
+	default:
+		return nil, fmt.Errorf("%w: %T can't hold named arguments", ErrUnsupportedType, arg)
+	}
+	return values, nil
+}
+
+// structValues adds the exported fields of the struct to values, named with
+// prefix. The fields of embedded structs are added as well, and then those of
+// nested structs, so fields closer to the top win, as in Go. parents holds the
+// pointers followed to reach the struct, which aren't followed again when a
+// struct refers back to one of its parents.
+func (f *Formatter) structValues(rv reflect.Value, prefix string, values map[string]any, parents []uintptr) {
+	type nested struct {
+		value   reflect.Value
+		prefix  string
+		parents []uintptr
+	}
+	var embedded, fields []nested
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, hasTag := field.Tag.Lookup("db")
+		// Embedded structs are followed even if unexported, as sqlx and
+		// encoding/json do, since their exported fields are promoted.
+		if name == "-" || !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		name, _, _ = strings.Cut(name, ",")
+		value := rv.Field(i)
+		elem, elemParents := value, parents
+		for elem.Kind() == reflect.Ptr && !elem.IsNil() && !slices.Contains(elemParents, elem.Pointer()) {
+			elemParents = append(elemParents[:len(elemParents):len(elemParents)], elem.Pointer())
+			elem = elem.Elem()
+		}
+		if field.Anonymous && !hasTag && elem.Kind() == reflect.Struct {
+			embedded = append(embedded, nested{elem, prefix, elemParents})
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		if _, ok := values[prefix+name]; !ok {
+			values[prefix+name] = value.Interface()
+		}
+		if f.isFlattened(elem) {
+			fields = append(fields, nested{elem, prefix + name + ".", elemParents})
+		}
+	}
+	for _, n := range append(embedded, fields...) {
+		f.structValues(n.value, n.prefix, values, n.parents)
+	}
+}
+
+// isFlattened reports whether the fields of the nested value are named
+// arguments as well: it must be a struct formatted as JSON, not a value such as
+// a time.Time or a driver.Valuer.
+func (f *Formatter) isFlattened(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	a := Argument{arg: v.Interface(), formatter: f}
+	return a.GetType() == Struct
+}
@@ -0,0 +1,106 @@
+package queryf
+
+import "time"
+
+type BindBase struct {
+	ID int `db:"id"`
+}
+
+type bindUser struct {
+	BindBase
+	Name     string `db:"name"`
+	Email    string
+	Password string `db:"-"`
+	nickname string
+}
+
+func (suite *QueryfTestSuite) TestBindNamed() {
+	user := bindUser{BindBase: BindBase{ID: 1}, Name: "John", Email: "john@example.com", Password: "x", nickname: "j"}
+	query, args, err := BindNamed(`SELECT * FROM users WHERE id = :id AND name = @name AND email = :email OR id = :id AND x = ':name'`, user)
+	suite.Nil(err)
+	suite.Equal(`SELECT * FROM users WHERE id = $1 AND name = $2 AND email = $3 OR id = $1 AND x = ':name'`, query)
+	suite.Equal([]any{1, "John", "john@example.com"}, args)
+
+	query, args, err = New(WithPlaceholderStyle(Question)).BindNamed(`SELECT :id, :v::text, :id`, map[string]int{"id": 1, "v": 2})
+	suite.Nil(err)
+	suite.Equal(`SELECT ?, ?::text, ?`, query)
+	suite.Equal([]any{1, 2, 1}, args)
+
+	query, args, err = BindNamed(`SELECT :id`, &user)
+	suite.Nil(err)
+	suite.Equal(`SELECT $1`, query)
+	suite.Equal([]any{1}, args)
+}
+
+func (suite *QueryfTestSuite) TestBindNamedErrors() {
+	_, _, err := BindNamed(`SELECT :id, :password`, bindUser{})
+	suite.ErrorIs(err, ErrMissingArgument)
+	suite.EqualError(err, "queryf: placeholder has no matching argument: :password")
+
+	_, _, err = BindNamed(`SELECT :id`, 1)
+	suite.ErrorIs(err, ErrUnsupportedType)
+}
+
+type bindAddress struct {
+	City    string `db:"city"`
+	Country string `db:"country"`
+}
+
+type bindAudit struct {
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type bindCustomer struct {
+	*BindBase
+	bindAudit
+	Name     string       `db:"name"`
+	Address  bindAddress  `db:"address"`
+	Billing  *bindAddress `db:"billing"`
+	Audit    bindAudit    `db:"audit"`
+	Referrer *bindCustomer
+}
+
+func (suite *QueryfTestSuite) TestBindNamedNested() {
+	created := time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)
+	customer := bindCustomer{
+		BindBase: &BindBase{ID: 1},
+		Name:     "John",
+		Address:  bindAddress{City: "Lisbon", Country: "PT"},
+		Billing:  &bindAddress{City: "Porto"},
+		Audit:    bindAudit{CreatedAt: created},
+		Referrer: &bindCustomer{Name: "Jane"},
+	}
+	query, args, err := BindNamed(`INSERT INTO customers VALUES (:id, :name, :address.city, :address.country, :billing.city, :audit.created_at)`, customer)
+	suite.Nil(err)
+	suite.Equal(`INSERT INTO customers VALUES ($1, $2, $3, $4, $5, $6)`, query)
+	suite.Equal([]any{1, "John", "Lisbon", "PT", "Porto", created}, args)
+
+	suite.Equal(`SELECT 'Lisbon', 'Jane', NULL, '2022-02-10T00:00:00Z'`,
+		FormatNamed(`SELECT :address.city, :referrer.name, :referrer.billing, :audit.created_at`, customer))
+	suite.Equal(`SELECT :referrer.referrer.name, 1.`, FormatNamed(`SELECT :referrer.referrer.name, :id.`, customer))
+	suite.Equal(`SELECT '{"City":"Lisbon","Country":"PT"}'`, FormatNamed(`SELECT :address`, customer))
+
+	customer.Referrer = &customer
+	suite.Equal(`SELECT 'John', 'John', :referrer.referrer.name`, FormatNamed(`SELECT :name, :referrer.name, :referrer.referrer.name`, &customer))
+}
+
+type bindRecord struct {
+	ID int `db:"id"`
+}
+
+type bindTimestamps struct {
+	CreatedAt string `db:"created_at"`
+}
+
+func (suite *QueryfTestSuite) TestBindNamedUnexportedEmbedded() {
+	arg := struct {
+		bindRecord
+		*bindTimestamps
+		Name string `db:"name"`
+	}{bindRecord{ID: 1}, &bindTimestamps{CreatedAt: "today"}, "John"}
+	query, args, err := BindNamed(`SELECT :id, :name, :created_at`, arg)
+	suite.Nil(err)
+	suite.Equal(`SELECT $1, $2, $3`, query)
+	suite.Equal([]any{1, "John", "today"}, args)
+	suite.Equal(`SELECT 1, 'John'`, FormatNamed(`SELECT :id, :name`, arg))
+}
@@ -0,0 +1,41 @@
+package queryf
+
+// BooleanStyle is how bool arguments are formatted.
+type BooleanStyle string
+
+const (
+	// TrueFalse formats booleans as true and false.
+	TrueFalse BooleanStyle = "truefalse"
+	// OneZero formats booleans as 1 and 0, as MySQL stores them.
+	OneZero BooleanStyle = "onezero"
+	// TF formats booleans as 't' and 'f', as Postgres prints them.
+	TF BooleanStyle = "tf"
+)
+
+// WithBooleanStyle sets how bool arguments are formatted. Defaults to the
+// dialect boolean literal, true and false for Postgres and 1 and 0 for the
+// other built-in dialects.
+func WithBooleanStyle(style BooleanStyle) Option {
+	return func(f *Formatter) {
+		f.booleanStyle = style
+	}
+}
+
+// formatBool returns the literal for b in the configured style.
+func (f *Formatter) formatBool(b bool) string {
+	switch f.booleanStyle {
+	case TrueFalse:
+		if b {
+			return "true"
+		}
+		return "false"
+	case OneZero:
+		return formatBoolNumber(b)
+	case TF:
+		if b {
+			return f.quote("t")
+		}
+		return f.quote("f")
+	}
+	return f.dialect.FormatBool(b)
+}
@@ -0,0 +1,20 @@
+package queryf
+
+import "database/sql"
+
+func (suite *QueryfTestSuite) TestBooleanStyle() {
+	suite.Equal(`SELECT true, false`, Format(`SELECT $1, $2`, true, false))
+	suite.Equal(`SELECT 1, 0`, New(WithBooleanStyle(OneZero)).Format(`SELECT $1, $2`, true, false))
+	suite.Equal(`SELECT 't', 'f'`, New(WithBooleanStyle(TF)).Format(`SELECT $1, $2`, true, false))
+	suite.Equal(`SELECT true, 0`, New(WithBooleanStyle(TrueFalse), WithDialect(MySQL)).Format(`SELECT ?, ?`, true, 0))
+	suite.Equal(`SELECT 1, NULL`, New(WithBooleanStyle(OneZero)).Format(`SELECT $1, $2`, sql.NullBool{Bool: true, Valid: true}, sql.NullBool{}))
+	suite.Equal(`SELECT '{"t","f"}'`, New(WithBooleanStyle(TF)).Format(`SELECT $1`, []bool{true, false}))
+}
+
+func (suite *QueryfTestSuite) TestBooleanStyleNamedBool() {
+	styles := map[BooleanStyle]string{TrueFalse: `SELECT true, false`, OneZero: `SELECT 1, 0`, TF: `SELECT 't', 'f'`}
+	for style, expected := range styles {
+		suite.Equal(expected, New(WithBooleanStyle(style)).Format(`SELECT $1, $2`, flag(true), flag(false)), string(style))
+	}
+	suite.Equal(`SELECT 1, 0`, New(WithDialect(MySQL)).Format(`SELECT ?, ?`, flag(true), flag(false)))
+}
@@ -0,0 +1,100 @@
+package queryf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Builder accumulates the fragments of a dynamic query, e.g. the conditions of
+// a search, and their arguments. Each $? of a fragment is numbered after the
+// arguments added before it, in the Formatter placeholder style, so fragments
+// can be added in any order. The zero value is ready to use.
+//
+// Example:
+//
+//	var b queryf.Builder
+//	b.Add("SELECT * FROM users WHERE deleted_at IS NULL")
+//	b.AddIf(status != "", "AND status = $?", status)
+//	b.AddIf(name != "", "AND name ILIKE $?", name)
+//	fmt.Println(b.Debug())
+//	// Output: SELECT * FROM users WHERE deleted_at IS NULL AND status = 'active'
+type Builder struct {
+	// Formatter formats Debug and sets the placeholder style. Defaults to the
+	// package level configuration.
+	Formatter *Formatter
+	fragments []string
+	args      []any
+	err       error
+}
+
+// Add appends the fragment and its arguments, one for each $? of the fragment.
+func (b *Builder) Add(fragment string, args ...any) *Builder {
+	style := b.formatter().style()
+	offset, count := len(b.args), 0
+	var sb strings.Builder
+	t := newTokenizer(fragment, syntax{style: Dollar, backslash: b.formatter().syntax().backslash})
+	for tok, ok := t.next(); ok; tok, ok = t.next() {
+		text := fragment[tok.start:tok.end]
+		if tok.kind == tokenText {
+			for {
+				before, after, found := strings.Cut(text, "$?")
+				sb.WriteString(before)
+				if !found {
+					break
+				}
+				count++
+				sb.WriteString(placeholderText(style, offset+count))
+				text = after
+			}
+			continue
+		}
+		sb.WriteString(text)
+	}
+	if count != len(args) && b.err == nil {
+		b.err = fmt.Errorf("%w: %d placeholders, %d arguments in %q", ErrArgumentCount, count, len(args), fragment)
+	}
+	if s := strings.TrimSpace(sb.String()); s != "" {
+		b.fragments = append(b.fragments, s)
+	}
+	b.args = append(b.args, args...)
+	return b
+}
+
+// AddIf appends the fragment and its arguments like Add if cond is true.
+func (b *Builder) AddIf(cond bool, fragment string, args ...any) *Builder {
+	if cond {
+		b.Add(fragment, args...)
+	}
+	return b
+}
+
+// ToSQL returns the query, its fragments joined with spaces, and its
+// arguments, or an error if a fragment was added with more or fewer arguments
+// than it has $? placeholders.
+func (b *Builder) ToSQL() (string, []any, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	return strings.Join(b.fragments, " "), b.args, nil
+}
+
+// ToSql is ToSQL, so a Builder is a Sqlizer.
+func (b *Builder) ToSql() (string, []any, error) {
+	return b.ToSQL()
+}
+
+// Debug returns the query formatted with its arguments, for logging.
+func (b *Builder) Debug() string {
+	query, args, err := b.ToSQL()
+	if err != nil {
+		return "<invalid query: " + err.Error() + ">"
+	}
+	return b.formatter().Format(query, args...)
+}
+
+func (b *Builder) formatter() *Formatter {
+	if b.Formatter == nil {
+		return Default()
+	}
+	return b.Formatter
+}
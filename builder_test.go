@@ -0,0 +1,37 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestBuilder() {
+	var b Builder
+	b.Add("SELECT * FROM users WHERE deleted_at IS NULL")
+	b.AddIf(true, "AND status = $?", "active").AddIf(false, "AND age > $?", 18)
+	b.Add(" AND created_at BETWEEN $? AND $? ", 1, 2)
+	b.Add("AND note <> '$?' ORDER BY id LIMIT $?", 10)
+
+	query, args, err := b.ToSQL()
+	suite.Nil(err)
+	suite.Equal(`SELECT * FROM users WHERE deleted_at IS NULL AND status = $1 AND created_at BETWEEN $2 AND $3 AND note <> '$?' ORDER BY id LIMIT $4`, query)
+	suite.Equal([]any{"active", 1, 2, 10}, args)
+	suite.Equal(`SELECT * FROM users WHERE deleted_at IS NULL AND status = 'active' AND created_at BETWEEN 1 AND 2 AND note <> '$?' ORDER BY id LIMIT 10`, b.Debug())
+
+	formatted, err := FormatSqlizer(&b)
+	suite.Nil(err)
+	suite.Equal(b.Debug(), formatted)
+}
+
+func (suite *QueryfTestSuite) TestBuilderStyle() {
+	b := Builder{Formatter: New(WithDialect(MySQL))}
+	b.Add("SELECT * FROM users WHERE id = $? AND name = $?", 1, "John")
+	query, _, err := b.ToSQL()
+	suite.Nil(err)
+	suite.Equal(`SELECT * FROM users WHERE id = ? AND name = ?`, query)
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND name = 'John'`, b.Debug())
+}
+
+func (suite *QueryfTestSuite) TestBuilderArgumentCount() {
+	var b Builder
+	b.Add("SELECT * FROM users WHERE id = $? AND name = $?", 1)
+	_, _, err := b.ToSQL()
+	suite.ErrorIs(err, ErrArgumentCount)
+	suite.EqualError(err, `queryf: number of placeholders doesn't match number of arguments: 2 placeholders, 1 arguments in "SELECT * FROM users WHERE id = $? AND name = $?"`)
+	suite.Equal(`<invalid query: `+err.Error()+`>`, b.Debug())
+}
@@ -0,0 +1,57 @@
+package queryf
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// BytesStyle is how []byte arguments are formatted.
+type BytesStyle string
+
+const (
+	// Hex formats bytes as the dialect binary literal, e.g. '\xdead'. It is the
+	// default.
+	Hex BytesStyle = "hex"
+	// Base64 formats bytes as decoded base64, e.g. decode('3q0=','base64').
+	// Only Postgres and MySQL support it, other dialects use Hex.
+	Base64 BytesStyle = "base64"
+	// Summary formats bytes as their size only, e.g. '<bytea 1.2 MB>'.
+	Summary BytesStyle = "summary"
+)
+
+// WithBytesStyle sets how []byte arguments are formatted. Defaults to Hex.
+func WithBytesStyle(style BytesStyle) Option {
+	return func(f *Formatter) {
+		f.bytesStyle = style
+	}
+}
+
+// formatBytes returns the literal for b in the configured style, truncating it
+// to the maximum bytes length.
+func (f *Formatter) formatBytes(b []byte) string {
+	if f.bytesStyle == Summary {
+		return f.quote("<bytea " + byteSize(len(b)) + ">")
+	}
+	b, marker := f.truncateBytes(b)
+	if f.bytesStyle == Base64 {
+		switch f.dialect {
+		case Postgres:
+			return "decode(" + f.quote(base64.StdEncoding.EncodeToString(b)) + ",'base64')" + marker
+		case MySQL:
+			return "FROM_BASE64(" + f.quote(base64.StdEncoding.EncodeToString(b)) + ")" + marker
+		}
+	}
+	return f.dialect.FormatBytes(b) + marker
+}
+
+// byteSize returns n as a human readable size, e.g. 1.2 MB.
+func byteSize(n int) string {
+	if n < 1000 {
+		return fmt.Sprintf("%d bytes", n)
+	}
+	size, units := float64(n)/1000, []string{"kB", "MB", "GB"}
+	for size >= 1000 && len(units) > 1 {
+		size, units = size/1000, units[1:]
+	}
+	return fmt.Sprintf("%.1f %s", size, units[0])
+}
@@ -0,0 +1,22 @@
+package queryf
+
+import "bytes"
+
+func (suite *QueryfTestSuite) TestBytesStyle() {
+	b := []byte{0xde, 0xad}
+	suite.Equal(`SELECT '\xdead'`, New(WithBytesStyle(Hex)).Format(`SELECT $1`, b))
+	suite.Equal(`SELECT decode('3q0=','base64')`, New(WithBytesStyle(Base64)).Format(`SELECT $1`, b))
+	suite.Equal(`SELECT FROM_BASE64('3q0=')`, New(WithBytesStyle(Base64), WithDialect(MySQL)).Format(`SELECT ?`, b))
+	suite.Equal(`SELECT X'dead'`, New(WithBytesStyle(Base64), WithDialect(SQLite)).Format(`SELECT ?`, b))
+	suite.Equal(`SELECT decode('3g==','base64')(+1 bytes)`, New(WithBytesStyle(Base64), WithMaxBytesLen(1)).Format(`SELECT $1`, b))
+
+	f := New(WithBytesStyle(Summary))
+	suite.Equal(`SELECT '<bytea 2 bytes>'`, f.Format(`SELECT $1`, b))
+	suite.Equal(`SELECT '<bytea 1.2 MB>'`, f.Format(`SELECT $1`, bytes.Repeat([]byte{1}, 1_200_000)))
+	suite.Equal(`SELECT '<bytea 0 bytes>', NULL`, f.Format(`SELECT $1, $2`, []byte{}, []byte(nil)))
+	suite.Equal(`SELECT '{"<bytea 2 bytes>"}'`, f.Format(`SELECT $1`, [][]byte{b}))
+
+	for n, size := range map[int]string{999: "999 bytes", 1000: "1.0 kB", 1_500_000_000: "1.5 GB", 2_000_000_000_000: "2000.0 GB"} {
+		suite.Equal(size, byteSize(n))
+	}
+}
@@ -0,0 +1,168 @@
+package queryf
+
+import (
+	"container/list"
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// WithCache keeps the last size formatted queries, keyed by the query and a
+// hash of its arguments, so queries logged over and over, e.g. by health
+// checks or polling loops, are only formatted once. Only queries whose
+// arguments are nil, booleans, numbers, strings, time.Time, []byte or slices
+// of them are cached, since the content of other values, e.g. pointers, can
+// change between calls. Formatters derived with With don't use the cache.
+func WithCache(size int) Option {
+	return func(f *Formatter) {
+		f.cache = nil
+		if size > 0 {
+			f.cache = newLRUCache[cacheKey, string](size)
+		}
+	}
+}
+
+// cacheSeed seeds the hashes of the cached arguments.
+var cacheSeed = maphash.MakeSeed()
+
+type cacheKey struct {
+	query string
+	args  uint64
+}
+
+// lruCache is a least recently used cache, safe for concurrent use.
+type lruCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	entries map[K]*list.Element
+	order   *list.List
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRUCache[K comparable, V any](size int) *lruCache[K, V] {
+	return &lruCache[K, V]{size: size, entries: make(map[K]*list.Element, size), order: list.New()}
+}
+
+// get returns the value cached for key, marking it as recently used.
+func (c *lruCache[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry[K, V]).value, true
+}
+
+// put caches the value for key, evicting the least recently used value when
+// the cache is full.
+func (c *lruCache[K, V]) put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[K, V]).key)
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+}
+
+// cacheKeyOf returns the cache key of the query and its arguments, or false if
+// any argument can't be hashed.
+func cacheKeyOf(query string, args []any) (cacheKey, bool) {
+	var h maphash.Hash
+	h.SetSeed(cacheSeed)
+	for _, arg := range args {
+		if !hashArg(&h, arg) {
+			return cacheKey{}, false
+		}
+	}
+	return cacheKey{query: query, args: h.Sum64()}, true
+}
+
+// hashArg writes the type and the value of arg to h, and returns false if its
+// value can't be hashed.
+func hashArg(h *maphash.Hash, arg any) bool {
+	if arg == nil {
+		h.WriteByte(0)
+		return true
+	}
+	if t, ok := arg.(time.Time); ok {
+		// UnixNano overflows outside of the years 1678 to 2262.
+		h.WriteString("time.Time")
+		writeUint64(h, uint64(t.Unix()))
+		writeUint64(h, uint64(t.Nanosecond()))
+		name, offset := t.Zone()
+		h.WriteString(t.Location().String())
+		h.WriteString(name)
+		writeUint64(h, uint64(offset))
+		return true
+	}
+	return hashValue(h, reflect.ValueOf(arg))
+}
+
+func hashValue(h *maphash.Hash, v reflect.Value) bool {
+	h.WriteString(v.Type().String())
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint64(h, math.Float64bits(v.Float()))
+	case reflect.String:
+		writeUint64(h, uint64(v.Len()))
+		h.WriteString(v.String())
+	case reflect.Slice:
+		if v.IsNil() {
+			h.WriteByte(0)
+			return true
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			writeUint64(h, uint64(v.Len()))
+			h.Write(v.Bytes())
+			return true
+		}
+		return hashElems(h, v)
+	case reflect.Array:
+		return hashElems(h, v)
+	default:
+		return false
+	}
+	return true
+}
+
+func hashElems(h *maphash.Hash, v reflect.Value) bool {
+	writeUint64(h, uint64(v.Len()))
+	for i := 0; i < v.Len(); i++ {
+		if !hashValue(h, v.Index(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func writeUint64(h *maphash.Hash, n uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], n)
+	h.Write(b[:])
+}
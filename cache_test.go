@@ -0,0 +1,117 @@
+package queryf
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingRedactor returns a redactor that never redacts but counts how many
+// arguments it was asked about.
+func countingRedactor(calls *int) Option {
+	var mu sync.Mutex
+	return WithRedactor(func(int, any) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		*calls++
+		return false
+	})
+}
+
+func (suite *QueryfTestSuite) TestCache() {
+	var calls int
+	f := New(WithCache(2), countingRedactor(&calls))
+	t := time.Date(2022, 2, 10, 10, 0, 0, 0, time.UTC)
+
+	suite.Equal(`SELECT 1, 'John'`, f.Format(`SELECT $1, $2`, 1, "John"))
+	suite.Equal(`SELECT 1, 'John'`, f.Format(`SELECT $1, $2`, 1, "John"))
+	suite.Equal(2, calls)
+
+	// Values of different types or contents are cached apart.
+	suite.Equal(`SELECT '1', 'John'`, f.Format(`SELECT $1, $2`, "1", "John"))
+	suite.Equal(4, calls)
+	suite.Equal(`SELECT '{1,2}'`, f.Format(`SELECT $1`, []int{1, 2}))
+	suite.Equal(`SELECT '{1,3}'`, f.Format(`SELECT $1`, []int{1, 3}))
+	suite.Equal(6, calls)
+
+	// The least recently used query was evicted.
+	suite.Equal(`SELECT '1', 'John'`, f.Format(`SELECT $1, $2`, "1", "John"))
+	suite.Equal(8, calls)
+	suite.Equal(`SELECT '{1,3}'`, f.Format(`SELECT $1`, []int{1, 3}))
+	suite.Equal(8, calls)
+
+	suite.Equal(`SELECT '2022-02-10T10:00:00Z', NULL, '\x01'`, f.Format(`SELECT $1, $2, $3`, t, nil, []byte{1}))
+	suite.Equal(`SELECT '2022-02-10T10:00:00Z', NULL, '\x01'`, f.Format(`SELECT $1, $2, $3`, t, nil, []byte{1}))
+	suite.Equal(11, calls)
+
+	// Times 2^64 nanoseconds apart have the same UnixNano.
+	old := time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC)
+	wrapped := old.Add(1 << 62).Add(1 << 62).Add(1 << 62).Add(1 << 62)
+	suite.Equal(old.UnixNano(), wrapped.UnixNano())
+	suite.Equal(Format(`SELECT $1`, old), f.Format(`SELECT $1`, old))
+	suite.Equal(Format(`SELECT $1`, wrapped), f.Format(`SELECT $1`, wrapped))
+}
+
+func (suite *QueryfTestSuite) TestCacheUncacheableArgs() {
+	var calls int
+	f := New(WithCache(10), countingRedactor(&calls))
+	id := 1
+	suite.Equal(`SELECT 1`, f.Format(`SELECT $1`, &id))
+	id = 2
+	suite.Equal(`SELECT 2`, f.Format(`SELECT $1`, &id))
+	suite.Equal(`SELECT '{"a":1}'`, f.Format(`SELECT $1`, map[string]int{"a": 1}))
+	suite.Equal(`SELECT '{"a":1}'`, f.Format(`SELECT $1`, map[string]int{"a": 1}))
+	suite.Equal(4, calls)
+
+	// Formatters derived with With don't use the cache of f.
+	suite.Equal(`SELECT null`, f.Format(`SELECT $1`, nil, WithNullLiteral("null")))
+	suite.Equal(`SELECT NULL`, f.Format(`SELECT $1`, nil))
+	suite.Equal(`SELECT NULL`, f.Format(`SELECT $1`, nil))
+	suite.Equal(6, calls)
+}
+
+func (suite *QueryfTestSuite) TestCacheStrictSafety() {
+	f := New(WithCache(10), WithStrictSafety())
+	suite.T().Setenv(UnlockEnv, "true")
+	suite.Equal(`SELECT 1`, f.Format(`SELECT $1`, 1))
+	suite.T().Setenv(UnlockEnv, "")
+	suite.Equal(`SELECT '[REDACTED]'`, f.Format(`SELECT $1`, 1))
+}
+
+func (suite *QueryfTestSuite) TestCacheDerivedFormatters() {
+	// Colored queries cached by Format aren't reused by the uncolored copies.
+	f := New(WithCache(10), WithColor())
+	suite.Equal("SELECT \x1b[32m1\x1b[0m", f.Format(`SELECT $1`, 1))
+	suite.Equal(New(WithColor()).FormatMarkdown(`SELECT $1`, 1), f.FormatMarkdown(`SELECT $1`, 1))
+	suite.Equal(New(WithColor()).Hash(`SELECT $1`, 1), f.Hash(`SELECT $1`, 1))
+	envelope, err := f.FormatJSON(`SELECT $1`, 1)
+	suite.Nil(err)
+	suite.NotContains(string(envelope), `\u001b`)
+
+	// The uncolored queries of Pretty don't end up in the cache either.
+	f = New(WithCache(10), WithKeywordColor())
+	suite.Equal(New(WithKeywordColor()).Pretty(`SELECT $1`, 1), f.Pretty(`SELECT $1`, 1))
+	suite.Equal("\x1b[34mSELECT\x1b[0m 1", f.Format(`SELECT $1`, 1))
+}
+
+func (suite *QueryfTestSuite) TestCacheConcurrently() {
+	f := New(WithCache(4))
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			suite.Equal(`SELECT `+string(rune('0'+i%8)), f.Format(`SELECT $1`, i%8))
+		}(i)
+	}
+	wg.Wait()
+}
+
+func BenchmarkFormatCache(b *testing.B) {
+	query, args := benchmarkQuery(10)
+	f := New(WithCache(100))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f.Format(query, args...)
+	}
+}
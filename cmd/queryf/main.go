@@ -0,0 +1,214 @@
+// Command queryf prints a query with its arguments interpolated, for debugging
+// queries found in shells and CI logs.
+//
+// The query is read from -query, or from stdin when the flag is empty. The
+// arguments are given either as a JSON array with -args, or one by one with
+// repeated -arg flags, optionally prefixed with a type hint:
+//
+//	queryf -query 'SELECT * FROM users WHERE id = $1 AND name = $2' -args '[1, "John"]'
+//	echo 'SELECT * FROM users WHERE id = ?' | queryf -dialect mysql -arg int:1
+//
+// The type hints are string, int, float, bool, time (RFC 3339), json and null.
+// Arguments without a hint are strings.
+//
+// With -log, each input line is a log line holding the query and its
+// arguments, as recognized by queryf.FormatFromLog:
+//
+//	grep 'msg=Query' app.log | queryf -log
+//
+//	** The printed queries are meant for debugging, never execute them. **
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucastamoios/queryf"
+)
+
+var dialects = map[string]queryf.Dialect{
+	"postgres":   queryf.Postgres,
+	"mysql":      queryf.MySQL,
+	"sqlite":     queryf.SQLite,
+	"sqlserver":  queryf.SQLServer,
+	"oracle":     queryf.Oracle,
+	"clickhouse": queryf.ClickHouse,
+	"bigquery":   queryf.BigQuery,
+	"cql":        queryf.CQL,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns its exit code.
+func run(arguments []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("queryf", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	query := flags.String("query", "", "the query to format, read from stdin if empty")
+	jsonArgs := flags.String("args", "", "the arguments as a JSON array")
+	var args argFlags
+	flags.Var(&args, "arg", "an argument, optionally prefixed with its type, e.g. int:1 (repeatable)")
+	dialect := flags.String("dialect", "postgres", "the dialect: postgres, mysql, sqlite, sqlserver, oracle, clickhouse, bigquery or cql")
+	pretty := flags.Bool("pretty", false, "lay the query out with a line per clause")
+	color := flags.Bool("color", false, "color keywords and values with ANSI escape codes")
+	fromLog := flags.Bool("log", false, "read the query and its arguments from log lines, one query per line")
+	if err := flags.Parse(arguments); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	d, ok := dialects[strings.ToLower(*dialect)]
+	if !ok {
+		fmt.Fprintf(stderr, "queryf: unknown dialect %q\n", *dialect)
+		return 2
+	}
+	if *jsonArgs != "" {
+		if len(args) > 0 {
+			fmt.Fprintln(stderr, "queryf: -args and -arg can't be used together")
+			return 2
+		}
+		var err error
+		if args, err = parseJSONArgs(*jsonArgs); err != nil {
+			fmt.Fprintf(stderr, "queryf: invalid -args: %v\n", err)
+			return 2
+		}
+	}
+	if *query == "" {
+		b, err := io.ReadAll(stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "queryf: reading stdin: %v\n", err)
+			return 1
+		}
+		*query = strings.TrimSpace(string(b))
+	}
+
+	opts := []queryf.Option{queryf.WithDialect(d)}
+	if *color {
+		opts = append(opts, queryf.WithColor(), queryf.WithKeywordColor())
+	}
+	f := queryf.New(opts...)
+	if *fromLog {
+		return formatLog(f, *query, *pretty, stdout, stderr)
+	}
+	if *pretty {
+		fmt.Fprintln(stdout, f.Pretty(*query, args...))
+	} else {
+		fmt.Fprintln(stdout, f.Format(*query, args...))
+	}
+	return 0
+}
+
+// formatLog prints the query of each log line, and returns 1 if any line has
+// no query.
+func formatLog(f *queryf.Formatter, lines string, pretty bool, stdout, stderr io.Writer) int {
+	code := 0
+	for _, line := range strings.Split(lines, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		query, err := f.FormatFromLog(line)
+		if err != nil {
+			fmt.Fprintf(stderr, "%v: %s\n", err, line)
+			code = 1
+			continue
+		}
+		if pretty {
+			query = f.Pretty(query)
+		}
+		fmt.Fprintln(stdout, query)
+	}
+	return code
+}
+
+// argFlags collects the repeated -arg flags.
+type argFlags []any
+
+func (a *argFlags) String() string {
+	return fmt.Sprint([]any(*a))
+}
+
+func (a *argFlags) Set(s string) error {
+	arg, err := parseArg(s)
+	if err != nil {
+		return err
+	}
+	*a = append(*a, arg)
+	return nil
+}
+
+// parseArg returns the value of an -arg flag, converted to the type of its
+// hint. Values without a known hint are strings.
+func parseArg(s string) (any, error) {
+	if s == "null" {
+		return nil, nil
+	}
+	hint, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return s, nil
+	}
+	switch hint {
+	case "string":
+		return value, nil
+	case "int":
+		return strconv.ParseInt(value, 10, 64)
+	case "float":
+		return strconv.ParseFloat(value, 64)
+	case "bool":
+		return strconv.ParseBool(value)
+	case "time":
+		return time.Parse(time.RFC3339Nano, value)
+	case "json":
+		if !json.Valid([]byte(value)) {
+			return nil, fmt.Errorf("invalid JSON %q", value)
+		}
+		return json.RawMessage(value), nil
+	}
+	return s, nil
+}
+
+// parseJSONArgs returns the elements of a JSON array. Integral numbers are
+// returned as int64, so they don't lose precision.
+func parseJSONArgs(s string) (argFlags, error) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+	var args []any
+	if err := dec.Decode(&args); err != nil {
+		return nil, err
+	}
+	for i, arg := range args {
+		args[i] = convertNumbers(arg)
+	}
+	return args, nil
+}
+
+// convertNumbers replaces the json.Number values in v with int64 or float64.
+func convertNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		n, _ := v.Float64()
+		return n
+	case []any:
+		for i, elem := range v {
+			v[i] = convertNumbers(elem)
+		}
+	case map[string]any:
+		for key, elem := range v {
+			v[key] = convertNumbers(elem)
+		}
+	}
+	return v
+}
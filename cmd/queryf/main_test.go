@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CLITestSuite struct {
+	suite.Suite
+}
+
+// run executes the command and returns its exit code, stdout and stderr.
+func (suite *CLITestSuite) run(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func (suite *CLITestSuite) TestQueryFlag() {
+	code, stdout, _ := suite.run("", "-query", `SELECT * FROM users WHERE id = $1 AND name = $2`, "-args", `[1, "John"]`)
+	suite.Equal(0, code)
+	suite.Equal("SELECT * FROM users WHERE id = 1 AND name = 'John'\n", stdout)
+}
+
+func (suite *CLITestSuite) TestStdin() {
+	code, stdout, _ := suite.run("SELECT * FROM users WHERE id = $1\n", "-args", `[9007199254740993]`)
+	suite.Equal(0, code)
+	suite.Equal("SELECT * FROM users WHERE id = 9007199254740993\n", stdout)
+}
+
+func (suite *CLITestSuite) TestJSONArgs() {
+	code, stdout, _ := suite.run(`SELECT $1, $2, $3, $4`, "-args", `[1.5, true, null, {"a": 1}]`)
+	suite.Equal(0, code)
+	suite.Equal("SELECT 1.5, true, NULL, '{\"a\":1}'\n", stdout)
+}
+
+func (suite *CLITestSuite) TestArgFlags() {
+	code, stdout, _ := suite.run(`SELECT $1, $2, $3, $4, $5, $6, $7, $8`,
+		"-arg", "int:1", "-arg", "float:1.5", "-arg", "bool:true", "-arg", "null",
+		"-arg", "string:int:2", "-arg", "John", "-arg", "time:2022-02-10T10:00:00Z", "-arg", `json:{"a":1}`)
+	suite.Equal(0, code)
+	suite.Equal("SELECT 1, 1.5, true, NULL, 'int:2', 'John', '2022-02-10T10:00:00Z', '{\"a\":1}'\n", stdout)
+}
+
+func (suite *CLITestSuite) TestDialect() {
+	code, stdout, _ := suite.run(`SELECT * FROM users WHERE id = ? AND active = ?`, "-dialect", "mysql", "-arg", "int:1", "-arg", "bool:true")
+	suite.Equal(0, code)
+	suite.Equal("SELECT * FROM users WHERE id = 1 AND active = 1\n", stdout)
+}
+
+func (suite *CLITestSuite) TestPretty() {
+	code, stdout, _ := suite.run(`SELECT id FROM users WHERE id = $1`, "-pretty", "-arg", "int:1")
+	suite.Equal(0, code)
+	suite.Equal("SELECT id\nFROM users\nWHERE id = 1\n", stdout)
+}
+
+func (suite *CLITestSuite) TestColor() {
+	code, stdout, _ := suite.run(`SELECT $1`, "-color", "-arg", "int:1")
+	suite.Equal(0, code)
+	suite.Equal("\x1b[34mSELECT\x1b[0m \x1b[32m1\x1b[0m\n", stdout)
+}
+
+func (suite *CLITestSuite) TestLog() {
+	lines := "level=INFO msg=Query sql=\"SELECT $1\" args=\"[1]\"\n\nlevel=INFO msg=Ready\n" +
+		`{"sql":"SELECT id FROM users WHERE name = $1","args":["John"]}` + "\n"
+	code, stdout, stderr := suite.run(lines, "-log", "-pretty")
+	suite.Equal(1, code)
+	suite.Equal("SELECT 1\nSELECT id\nFROM users\nWHERE name = 'John'\n", stdout)
+	suite.Equal("queryf: no query found in log line: level=INFO msg=Ready\n", stderr)
+}
+
+func (suite *CLITestSuite) TestErrors() {
+	code, _, stderr := suite.run(`SELECT 1`, "-dialect", "db2")
+	suite.Equal(2, code)
+	suite.Equal("queryf: unknown dialect \"db2\"\n", stderr)
+
+	code, _, stderr = suite.run(`SELECT $1`, "-args", `{"a": 1}`)
+	suite.Equal(2, code)
+	suite.Contains(stderr, "queryf: invalid -args")
+
+	code, _, stderr = suite.run(`SELECT $1`, "-args", `[1]`, "-arg", "int:1")
+	suite.Equal(2, code)
+	suite.Equal("queryf: -args and -arg can't be used together\n", stderr)
+
+	code, _, stderr = suite.run(`SELECT $1`, "-arg", "int:one")
+	suite.Equal(2, code)
+	suite.Contains(stderr, `invalid value "int:one" for flag -arg`)
+}
+
+func TestCLITestSuite(t *testing.T) {
+	suite.Run(t, new(CLITestSuite))
+}
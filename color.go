@@ -0,0 +1,61 @@
+package queryf
+
+import "strings"
+
+// ANSI escape codes used by WithColor and WithKeywordColor.
+const (
+	colorValue   = "\x1b[32m"
+	colorKeyword = "\x1b[34m"
+	colorReset   = "\x1b[0m"
+)
+
+// WithColor highlights the formatted arguments with ANSI colors. It is meant
+// for output written to a terminal, so enable it only when that is the case,
+// e.g. when term.IsTerminal(int(os.Stdout.Fd())) is true.
+func WithColor() Option {
+	return func(f *Formatter) {
+		f.color = true
+	}
+}
+
+// WithKeywordColor highlights the SQL keywords of the query with ANSI colors,
+// in addition to the arguments highlighted by WithColor.
+func WithKeywordColor() Option {
+	return func(f *Formatter) {
+		f.keywordColor = true
+	}
+}
+
+// colorize wraps s in the ANSI color code.
+func colorize(s, color string) string {
+	return color + s + colorReset
+}
+
+// colorKeywords returns the query with the keywords outside of literals,
+// quoted identifiers and comments highlighted.
+func colorKeywords(query string, s syntax) string {
+	var b strings.Builder
+	t := newTokenizer(query, s)
+	for tok, ok := t.next(); ok; tok, ok = t.next() {
+		text := query[tok.start:tok.end]
+		if tok.kind != tokenText {
+			b.WriteString(text)
+			continue
+		}
+		for i := 0; i < len(text); {
+			end := i + 1
+			if isNameChar(text[i]) {
+				for end < len(text) && isNameChar(text[end]) {
+					end++
+				}
+			}
+			if word := text[i:end]; keywords[strings.ToLower(word)] && (i == 0 || text[i-1] != '.') {
+				b.WriteString(colorize(word, colorKeyword))
+			} else {
+				b.WriteString(word)
+			}
+			i = end
+		}
+	}
+	return b.String()
+}
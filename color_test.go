@@ -0,0 +1,17 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestColor() {
+	f := New(WithColor())
+	suite.Equal("SELECT * FROM t WHERE a = \x1b[32m1\x1b[0m AND b = \x1b[32m'x'\x1b[0m", f.Format(`SELECT * FROM t WHERE a = $1 AND b = $2`, 1, "x"))
+	suite.Equal("SELECT \x1b[32m1\x1b[0m", f.FormatNamed(`SELECT :a`, map[string]any{"a": 1}))
+
+	f = New(WithKeywordColor())
+	suite.Equal("\x1b[34mSELECT\x1b[0m t.from, 'where', \"and\" \x1b[34mFROM\x1b[0m t -- select\n\x1b[34mWHERE\x1b[0m a = 1",
+		f.Format("SELECT t.from, 'where', \"and\" FROM t -- select\nWHERE a = $1", 1))
+}
+
+func (suite *QueryfTestSuite) TestPrettyColor() {
+	f := New(WithColor(), WithKeywordColor(), WithKeywordCase(Upper))
+	suite.Equal("\x1b[34mSELECT\x1b[0m a\n\x1b[34mFROM\x1b[0m t\n\x1b[34mWHERE\x1b[0m a = \x1b[32m1\x1b[0m\n  \x1b[34mAND\x1b[0m b \x1b[34mIN\x1b[0m (\x1b[32m'x'\x1b[0m, \x1b[32m'y'\x1b[0m)",
+		f.Pretty(`select a from t where a = $1 and b in ($2, $3)`, 1, "x", "y"))
+}
@@ -0,0 +1,78 @@
+package queryf
+
+import "strings"
+
+// WithSingleLine formats queries on a single line, as Compact does, for log
+// systems that split or mangle multi-line messages. String arguments holding
+// newlines are written as Postgres escape strings, as with WithEscapeStrings,
+// while literals in the query itself are kept as written.
+func WithSingleLine() Option {
+	return func(f *Formatter) {
+		f.singleLine = true
+	}
+}
+
+// Compact returns the query on a single line: whitespace outside of literals
+// collapses into single spaces and the query is trimmed. Comments are kept,
+// with -- comments turned into /* */ comments so they don't swallow the rest
+// of the query.
+//
+// Example:
+//
+//	fmt.Println(queryf.Compact(`
+//		SELECT *
+//		FROM users -- active only
+//		WHERE active
+//	`))
+//	// Output: SELECT * FROM users /* active only */ WHERE active
+func Compact(query string) string {
+	return Default().Compact(query)
+}
+
+// Compact returns the query on a single line, using the Formatter placeholder
+// style. See the package level Compact for details.
+func (f *Formatter) Compact(query string) string {
+	return compact(query, f.syntax())
+}
+
+func compact(query string, s syntax) string {
+	var b strings.Builder
+	space := false
+	write := func(s string) {
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteString(s)
+	}
+	t := newTokenizer(query, s)
+	for tok, ok := t.next(); ok; tok, ok = t.next() {
+		text := query[tok.start:tok.end]
+		switch {
+		case tok.kind == tokenText:
+			for i := 0; i < len(text); i++ {
+				if c := text[i]; c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+					space = true
+				} else {
+					write(text[i : i+1])
+				}
+			}
+		case tok.kind == tokenComment && strings.HasPrefix(text, "--"):
+			space = true
+			if words := strings.Fields(text[2:]); len(words) > 0 {
+				write("/* " + strings.ReplaceAll(strings.Join(words, " "), "*/", "* /") + " */")
+				space = true
+			}
+		case tok.kind == tokenComment:
+			write(strings.Join(strings.Fields(text), " "))
+		default:
+			write(text)
+		}
+	}
+	return b.String()
+}
+
+// hasNewline reports whether s spans several lines.
+func hasNewline(s string) bool {
+	return strings.ContainsAny(s, "\n\r")
+}
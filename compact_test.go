@@ -0,0 +1,25 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestCompact() {
+	suite.Equal(`SELECT * FROM users /* active only */ WHERE active`, Compact(`
+		SELECT *
+		FROM users -- active only
+		WHERE active
+	`))
+	suite.Equal(`SELECT 'a  b', "x  y" /* block comment */ FROM t`, Compact("SELECT  'a  b',\n\"x  y\" /* block\n   comment */ FROM t"))
+	suite.Equal(`SELECT 1 FROM t`, Compact("SELECT 1 --\nFROM t"))
+	suite.Equal(`SELECT 1 /* a * / b */`, Compact("SELECT 1 -- a */ b"))
+	suite.Equal(`SELECT $1, $body$ line 1`+"\n"+`line 2 $body$`, Compact("SELECT\n$1,\n$body$ line 1\nline 2 $body$"))
+}
+
+func (suite *QueryfTestSuite) TestWithSingleLine() {
+	f := New(WithSingleLine())
+	query := `
+		SELECT *
+		FROM users
+		WHERE id = $1 AND bio = $2`
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND bio = E'line 1\nline 2'`, f.Format(query, 1, "line 1\nline 2"))
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND bio = 'tab	only'`, f.Format(query, 1, "tab\tonly"))
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND bio = E'line 1\nline 2'`, f.Compile(query).Format(1, "line 1\nline 2"))
+	suite.Equal(`SELECT 1`, f.Format("SELECT\n\t1"))
+}
@@ -0,0 +1,130 @@
+package queryf
+
+// compiledCacheSize is the number of compiled queries kept by Compile.
+const compiledCacheSize = 1024
+
+// compiledKey identifies a compiled query. The syntax, the keyword
+// colors and the single line layout are the only configuration that changes
+// how a query is compiled.
+type compiledKey struct {
+	query        string
+	syntax       syntax
+	keywordColor bool
+	singleLine   bool
+}
+
+// compiledTemplate is the query split around its placeholders, shared by the
+// CompiledQuery values of every Formatter with the same compiledKey.
+type compiledTemplate struct {
+	query        string
+	placeholders []placeholder
+}
+
+var compiledTemplates = newLRUCache[compiledKey, *compiledTemplate](compiledCacheSize)
+
+// CompiledQuery is a query whose placeholders were located once by Compile, so
+// formatting it only substitutes the arguments. It is safe for concurrent use.
+type CompiledQuery struct {
+	formatter *Formatter
+	query     string
+	template  *compiledTemplate
+}
+
+// Compile tokenizes the query and locates its placeholders once, for queries
+// formatted over and over in hot paths. The compiled queries are cached by
+// query, so calling Compile again with the same query is cheap.
+//
+// Example:
+//
+//	q := queryf.Compile("SELECT * FROM users WHERE id = $1")
+//	fmt.Println(q.Format(1))
+//	// Output: SELECT * FROM users WHERE id = 1
+func Compile(query string) *CompiledQuery {
+	return Default().Compile(query)
+}
+
+// Compile tokenizes the query and locates its placeholders once, using the
+// Formatter configuration. See the package level Compile for details.
+func (f *Formatter) Compile(query string) *CompiledQuery {
+	key := compiledKey{query: query, syntax: f.syntax(), keywordColor: f.keywordColor, singleLine: f.singleLine}
+	t, ok := compiledTemplates.get(key)
+	if !ok {
+		t = compileTemplate(key)
+		compiledTemplates.put(key, t)
+	}
+	return &CompiledQuery{formatter: f, query: query, template: t}
+}
+
+func compileTemplate(key compiledKey) *compiledTemplate {
+	query := key.query
+	if key.singleLine {
+		query = compact(query, key.syntax)
+	}
+	if key.keywordColor {
+		query = colorKeywords(query, key.syntax)
+	}
+	placeholders := scanPlaceholders(query, key.syntax)
+	for _, p := range placeholders {
+		if !p.escaped {
+			return &compiledTemplate{query: query, placeholders: placeholders}
+		}
+	}
+	// Without placeholders the query is unescaped once, so Format returns it
+	// as is.
+	unescaped := substitute(query, key.syntax, func(placeholder) (string, bool) { return "", false })
+	return &compiledTemplate{query: unescaped}
+}
+
+// String returns the query as given to Compile.
+func (q *CompiledQuery) String() string {
+	return q.query
+}
+
+// Format returns the compiled query with the arguments formatted, like the
+// Format method of its Formatter, including the options passed among the
+// arguments.
+func (q *CompiledQuery) Format(args ...any) string {
+	if opts, rest := callOptions(args); opts != nil {
+		return q.formatter.With(opts...).Compile(q.query).Format(rest...)
+	}
+	// pgx.NamedArgs and other query rewriters replace the compiled query.
+	query, args := rewriteQuery(q.query, args)
+	if query != q.query {
+		return q.formatter.Compile(query).Format(args...)
+	}
+	t := q.template
+	if len(t.placeholders) == 0 {
+		return t.query
+	}
+	// Each argument is formatted once, however many placeholders refer to it.
+	var literals [16]string
+	var known [16]bool
+	formatted, done := literals[:], known[:]
+	if len(args) > len(literals) {
+		formatted, done = make([]string, len(args)), make([]bool, len(args))
+	}
+	buf := bufferPool.Get().(*[]byte)
+	b := (*buf)[:0]
+	last := 0
+	for _, p := range t.placeholders {
+		if p.escaped {
+			b = append(b, t.query[last:p.start]...)
+			b = append(b, '?')
+			last = p.end
+			continue
+		}
+		i := p.index - 1
+		if i < 0 || i >= len(args) {
+			continue
+		}
+		if !done[i] {
+			formatted[i] = q.formatter.formatArg(p.index, args[i])
+			done[i] = true
+		}
+		b = append(b, t.query[last:p.start]...)
+		b = append(b, formatted[i]...)
+		last = p.end
+	}
+	b = append(b, t.query[last:]...)
+	return releaseBuffer(buf, b)
+}
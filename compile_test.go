@@ -0,0 +1,49 @@
+package queryf
+
+import (
+	"strings"
+	"testing"
+)
+
+func (suite *QueryfTestSuite) TestCompile() {
+	q := Compile(`SELECT * FROM users WHERE id = $1 AND name = $2 OR id = $1 -- $3`)
+	suite.Equal(`SELECT * FROM users WHERE id = $1 AND name = $2 OR id = $1 -- $3`, q.String())
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND name = 'John' OR id = 1 -- $3`, q.Format(1, "John"))
+	suite.Equal(`SELECT * FROM users WHERE id = 2 AND name = $2 OR id = 2 -- $3`, q.Format(2))
+	suite.Equal(`SELECT * FROM users WHERE id = null AND name = $2 OR id = null -- $3`, q.Format(nil, WithNullLiteral("null")))
+	suite.Equal(`SELECT 1`, Compile(`SELECT 1`).Format(1))
+
+	suite.Same(Compile(`SELECT $1`).template, Compile(`SELECT $1`).template)
+	suite.NotSame(Compile(`SELECT $1`).template, New(WithPlaceholderStyle(Question)).Compile(`SELECT $1`).template)
+
+	f := New(WithPlaceholderStyle(Question), WithKeywordColor(), WithRedactedParams(2))
+	suite.Equal("\x1b[34mSELECT\x1b[0m 1, '[REDACTED]'", f.Compile(`SELECT ?, ?`).Format(1, "secret"))
+	suite.Equal(f.Format(`SELECT ?, ?`, 1, "secret"), f.Compile(`SELECT ?, ?`).Format(1, "secret"))
+
+	question := New(WithPlaceholderStyle(Question))
+	suite.Equal(`SELECT data ? 'a', 1, '??'`, question.Compile(`SELECT data ?? 'a', ?, '??'`).Format(1))
+	suite.Equal(`SELECT data ?| array['a']`, question.Compile(`SELECT data ??| array['a']`).Format())
+
+	query, args := benchmarkQuery(20)
+	suite.Equal(Format(query, args...), Compile(query).Format(args...))
+	long := `SELECT $1, '` + strings.Repeat("x", 2*maxPooledBuffer) + `'`
+	suite.Equal(Format(long, 1), Compile(long).Format(1))
+}
+
+func (suite *QueryfTestSuite) TestCompileEviction() {
+	for i := 0; i <= compiledCacheSize; i++ {
+		Compile(`SELECT $1 -- ` + strings.Repeat("x", i))
+	}
+	_, ok := compiledTemplates.get(compiledKey{query: `SELECT $1 -- `, syntax: syntax{style: Dollar}})
+	suite.False(ok)
+	suite.Equal(`SELECT 1 -- `, Compile(`SELECT $1 -- `).Format(1))
+}
+
+func BenchmarkCompiledFormat(b *testing.B) {
+	query, args := benchmarkQuery(10)
+	q := Compile(query)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q.Format(args...)
+	}
+}
@@ -0,0 +1,102 @@
+package queryf
+
+import "strings"
+
+var (
+	// copyTextEscaper escapes the values of the COPY text format.
+	copyTextEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+	// csvQuoteEscaper escapes the quoted values of the COPY CSV format.
+	csvQuoteEscaper = strings.NewReplacer(`"`, `""`)
+)
+
+// FormatCopy returns the rows in the text format read by Postgres
+// COPY ... FROM STDIN, to debug or reproduce pq.CopyIn and pgx.CopyFrom loads:
+// one line per row, tab separated columns, \N for NULL and backslash escapes.
+// Values are written as Postgres reads them, e.g. arrays as {1,2}, rows as
+// (a,1,t) and bytes as \x escapes. The 1-based column is the index passed to
+// the redactors. psql also expects a \. line after the data.
+//
+// Example:
+//
+//	fmt.Print(queryf.FormatCopy([][]any{{1, "John\tDoe"}, {2, nil}}))
+//	// Output:
+//	// 1	John\tDoe
+//	// 2	\N
+func FormatCopy(rows [][]any) string {
+	return Default().FormatCopy(rows)
+}
+
+// FormatCopy returns the rows in the COPY text format, formatted with the
+// Formatter configuration. See the package level FormatCopy for details.
+func (f *Formatter) FormatCopy(rows [][]any) string {
+	return f.formatCopy(rows, "\t", func(v string, null bool) string {
+		if null {
+			return `\N`
+		}
+		return copyTextEscaper.Replace(v)
+	})
+}
+
+// FormatCopyCSV returns the rows in the format read by Postgres
+// COPY ... FROM STDIN WITH (FORMAT csv): comma separated columns, empty
+// unquoted values for NULL, and double quotes around the values that need
+// them. Empty strings are quoted, so they aren't read as NULL.
+//
+// Example:
+//
+//	fmt.Print(queryf.FormatCopyCSV([][]any{{1, "Doe, John"}, {2, nil}, {3, ""}}))
+//	// Output:
+//	// 1,"Doe, John"
+//	// 2,
+//	// 3,""
+func FormatCopyCSV(rows [][]any) string {
+	return Default().FormatCopyCSV(rows)
+}
+
+// FormatCopyCSV returns the rows in the COPY CSV format, formatted with the
+// Formatter configuration. See the package level FormatCopyCSV for details.
+func (f *Formatter) FormatCopyCSV(rows [][]any) string {
+	return f.formatCopy(rows, ",", func(v string, null bool) string {
+		if null {
+			return ""
+		}
+		if v == "" || strings.ContainsAny(v, ",\"\n\r") {
+			return `"` + csvQuoteEscaper.Replace(v) + `"`
+		}
+		return v
+	})
+}
+
+// formatCopy writes a line per row, with its columns separated by sep and
+// each value encoded by escape.
+func (f *Formatter) formatCopy(rows [][]any, sep string, escape func(v string, null bool) string) string {
+	// COPY reads the Postgres text representation of the values, whatever the
+	// dialect and literal options of the Formatter.
+	pg := *f
+	pg.cache = nil
+	pg.dialect = Postgres
+	pg.nullLiteral = "NULL"
+	pg.quoter = nil
+	pg.escapeStrings = false
+	pg.unicodeEscapes = false
+	pg.bytesStyle = Hex
+	pg.moneyCast = false
+	pg.copyText = true
+	pg.color = false
+	var b strings.Builder
+	for _, row := range rows {
+		for j, arg := range row {
+			if j > 0 {
+				b.WriteString(sep)
+			}
+			s, marker := splitTruncationMarker(pg.literal(j+1, arg))
+			null := s == pg.nullLiteral && marker == ""
+			if v, ok := unquote(uncast(s)); ok {
+				s = v
+			}
+			b.WriteString(escape(s+marker, null))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
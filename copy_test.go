@@ -0,0 +1,56 @@
+package queryf
+
+import (
+	"database/sql"
+	"math/big"
+	"time"
+)
+
+func (suite *QueryfTestSuite) TestFormatCopy() {
+	t := time.Date(2022, 2, 10, 10, 0, 0, 0, time.UTC)
+	rows := [][]any{
+		{1, "John\tDoe", true, 1.5},
+		{2, nil, sql.NullString{}, "back\\slash\nnew line"},
+		{3, []string{"a", "b c"}, []byte{0xde, 0xad}, t},
+		{4, "NULL", "it's", map[string]int{"a": 1}},
+	}
+	suite.Equal("1\tJohn\\tDoe\ttrue\t1.5\n"+
+		"2\t\\N\t\\N\tback\\\\slash\\nnew line\n"+
+		"3\t{\"a\",\"b c\"}\t\\\\xdead\t2022-02-10T10:00:00Z\n"+
+		"4\tNULL\tit's\t{\"a\":1}\n", FormatCopy(rows))
+	suite.Equal("", FormatCopy(nil))
+	suite.Equal("0.125\t0.33333333333333333333\t-0.66666666666666666667\n",
+		FormatCopy([][]any{{big.NewRat(1, 8), big.NewRat(1, 3), big.NewRat(-2, 3)}}))
+
+	f := New(WithDialect(MySQL), WithNullLiteral("null"), WithEscapeStrings(), WithRedactedParams(2))
+	suite.Equal("true\t[REDACTED]\n\\N\t[REDACTED]\n", f.FormatCopy([][]any{{true, "secret"}, {nil, nil}}))
+}
+
+func (suite *QueryfTestSuite) TestFormatCopyMoneyAndRows() {
+	f := New(WithMinorUnits(cents(0), 2), WithMoneyCast())
+	suite.Equal("12.34\n", f.FormatCopy([][]any{{cents(1234)}}))
+
+	rows := [][]any{
+		{Row([]any{"a", 1, true}).As("mytype")},
+		{Row([]any{"", nil, `say "hi"`, "a,b", []int{1, 2}, `back\slash`})},
+		{Row(struct{ Inner RowValue }{Row([]any{1, "x y"})})},
+		{Row(nil)},
+	}
+	suite.Equal(`(a,1,true)
+("",,"say ""hi""","a,b","{1,2}","back\\\\slash")
+("(1,""x y"")")
+\N
+`, FormatCopy(rows))
+	suite.Equal(`SELECT ROW('a', 1)`, Format(`SELECT $1`, Row([]any{"a", 1})))
+}
+
+func (suite *QueryfTestSuite) TestFormatCopyCSV() {
+	rows := [][]any{
+		{1, "Doe, John", nil},
+		{2, `say "hi"`, ""},
+		{3, "line\nbreak", []int{1, 2}},
+	}
+	suite.Equal("1,\"Doe, John\",\n"+
+		"2,\"say \"\"hi\"\"\",\"\"\n"+
+		"3,\"line\nbreak\",\"{1,2}\"\n", FormatCopyCSV(rows))
+}
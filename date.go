@@ -0,0 +1,65 @@
+package queryf
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// civilPkgPath is the package of the civil.Date, civil.Time and
+// civil.DateTime types, which are detected without importing it.
+const civilPkgPath = "cloud.google.com/go/civil"
+
+// WithDateOnly formats time.Time arguments at midnight as dates, e.g.
+// '2023-01-01'::date, and arguments on January 1st of year 0, as returned by
+// time.Parse for clock-only layouts, as times of day, e.g. '13:45:00'::time.
+// civil.Date and civil.Time values are always formatted this way.
+func WithDateOnly() Option {
+	return func(f *Formatter) {
+		f.dateOnly = true
+	}
+}
+
+func isCivil(t reflect.Type, name string) bool {
+	return isNamedType(t, civilPkgPath, name)
+}
+
+func (a *Argument) isDate() bool {
+	if isCivil(a.getReflectedType(), "Date") {
+		return true
+	}
+	t, ok := a.arg.(time.Time)
+	if !ok || !a.formatter.dateOnly {
+		return false
+	}
+	t = a.formatter.adjustTime(t)
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+func (a *Argument) isTimeOfDay() bool {
+	if isCivil(a.getReflectedType(), "Time") {
+		return true
+	}
+	t, ok := a.arg.(time.Time)
+	if !ok || !a.formatter.dateOnly {
+		return false
+	}
+	t = a.formatter.adjustTime(t)
+	return t.Year() == 0 && t.Month() == time.January && t.Day() == 1
+}
+
+func (a *Argument) formatDate() string {
+	s := fmt.Sprint(a.arg)
+	if t, ok := a.arg.(time.Time); ok {
+		s = a.formatter.adjustTime(t).Format("2006-01-02")
+	}
+	return a.formatter.cast(a.formatter.quote(s), "date")
+}
+
+func (a *Argument) formatTimeOfDay() string {
+	s := fmt.Sprint(a.arg)
+	if t, ok := a.arg.(time.Time); ok {
+		s = a.formatter.adjustTime(t).Format("15:04:05.999999999")
+	}
+	return a.formatter.cast(a.formatter.quote(s), "time")
+}
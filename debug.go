@@ -0,0 +1,53 @@
+package queryf
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+)
+
+// DB is the part of *sql.DB, *sql.Tx and *sql.Conn used to run queries.
+type DB interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// DebugQueryContext runs the query with db.QueryContext and logs it formatted,
+// with the time it took and its error, if any. A nil logger uses
+// slog.Default(). Swapping a QueryContext call for it is a quick way to see
+// what a call site runs.
+//
+// Example:
+//
+//	rows, err := queryf.DebugQueryContext(ctx, db, nil, "SELECT * FROM users WHERE id = $1", 1)
+//	// INFO query query="SELECT * FROM users WHERE id = 1" duration=1.2ms
+func DebugQueryContext(ctx context.Context, db DB, logger *slog.Logger, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := db.QueryContext(ctx, query, args...)
+	logQuery(ctx, logger, query, args, time.Since(start), err)
+	return rows, err
+}
+
+// DebugExecContext runs the query with db.ExecContext and logs it like
+// DebugQueryContext.
+func DebugExecContext(ctx context.Context, db DB, logger *slog.Logger, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := db.ExecContext(ctx, query, args...)
+	logQuery(ctx, logger, query, args, time.Since(start), err)
+	return result, err
+}
+
+// logQuery logs the query at the info level, or at the error level if it
+// failed.
+func logQuery(ctx context.Context, logger *slog.Logger, query string, args []any, duration time.Duration, err error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	attrs := []slog.Attr{Attr(query, args...), slog.Duration("duration", duration)}
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "query", append(attrs, slog.Any("error", err))...)
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelInfo, "query", attrs...)
+}
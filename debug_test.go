@@ -0,0 +1,58 @@
+package queryf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// newTestLogger returns a logger writing text records without time and
+// duration to buf.
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "duration" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func (suite *QueryfTestSuite) TestDebugQueryContext() {
+	fake, db := newFakeDB("a", "b")
+	defer db.Close()
+	var buf bytes.Buffer
+
+	rows, err := DebugQueryContext(context.Background(), db, newTestLogger(&buf), `SELECT name FROM t WHERE id = $1`, 1)
+	suite.Nil(err)
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		suite.Nil(rows.Scan(&name))
+		names = append(names, name)
+	}
+	suite.Equal([]string{"a", "b"}, names)
+	suite.Equal([]string{`SELECT name FROM t WHERE id = $1`}, fake.statements)
+	suite.Equal("level=INFO msg=query query=\"SELECT name FROM t WHERE id = 1\"\n", buf.String())
+}
+
+func (suite *QueryfTestSuite) TestDebugExecContext() {
+	fake, db := newFakeDB()
+	defer db.Close()
+	var buf bytes.Buffer
+
+	result, err := DebugExecContext(context.Background(), db, newTestLogger(&buf), `DELETE FROM t WHERE id = $1`, 1)
+	suite.Nil(err)
+	n, _ := result.RowsAffected()
+	suite.Equal(int64(1), n)
+	suite.Equal("level=INFO msg=query query=\"DELETE FROM t WHERE id = 1\"\n", buf.String())
+
+	buf.Reset()
+	fake.err = errors.New("boom")
+	_, err = DebugExecContext(context.Background(), db, newTestLogger(&buf), `DELETE FROM t`)
+	suite.EqualError(err, "boom")
+	suite.Equal("level=ERROR msg=query query=\"DELETE FROM t\" error=boom\n", buf.String())
+}
@@ -0,0 +1,43 @@
+package queryf
+
+import (
+	"sync"
+	"time"
+)
+
+func (suite *QueryfTestSuite) TestSetDefault() {
+	defer SetDefault(nil)
+	suite.Same(builtinFormatter, Default())
+
+	f := New(WithDialect(MySQL), WithRedactedParams(2))
+	SetDefault(f)
+	suite.Same(f, Default())
+	suite.Equal(`SELECT 1, '[REDACTED]'`, Format(`SELECT ?, ?`, true, "secret"))
+	suite.Equal(`SELECT true`, New().Format(`SELECT $1`, true))
+	suite.Equal(`SELECT 1`, Lazy(`SELECT ?`, 1).String())
+
+	recorder := NewRecorder(1)
+	recorder.Record(`SELECT ?`, []any{true}, time.Millisecond, nil)
+	suite.Equal(`SELECT 1`, recorder.Queries()[0].Query)
+
+	SetDefault(nil)
+	suite.Same(builtinFormatter, Default())
+	suite.Equal(`SELECT true`, Format(`SELECT $1`, true))
+}
+
+func (suite *QueryfTestSuite) TestSetDefaultConcurrently() {
+	defer SetDefault(nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetDefault(New(WithNullLiteral("null")))
+		}()
+		go func() {
+			defer wg.Done()
+			suite.Contains([]string{`SELECT NULL`, `SELECT null`}, Format(`SELECT $1`, nil))
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,280 @@
+package queryf
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// defaultMaxDepth is the number of nesting levels formatted, unless
+// WithMaxDepth is given.
+const defaultMaxDepth = 100
+
+// depthMarker replaces the values nested deeper than the maximum depth.
+const depthMarker = "<max depth>"
+
+// WithMaxDepth formats values nested up to n levels deep, such as the elements
+// of arrays, the fields of structs and maps, and the values of pointers and
+// valuers, replacing deeper values with '<max depth>'. Defaults to 100.
+func WithMaxDepth(n int) Option {
+	return func(f *Formatter) {
+		f.maxDepth = n
+	}
+}
+
+func (f *Formatter) depthLimit() int {
+	if f.maxDepth <= 0 {
+		return defaultMaxDepth
+	}
+	return f.maxDepth
+}
+
+// marshalNested returns the JSON literal of a map or struct, or the error of
+// a MarshalJSON method. Values encoding/json rejects, e.g. maps with struct
+// keys, channels or cycles, and values nested deeper than the maximum depth are
+// encoded by jsonEncoder.
+func (a *Argument) marshalNested() (string, error) {
+	v := addressable(a.getReflectedValue())
+	s, err := marshalJSON(v.Interface())
+	var marshalerErr *json.MarshalerError
+	if errors.As(err, &marshalerErr) {
+		return "", marshalerErr.Unwrap()
+	}
+	if err == nil && a.depth+jsonDepth(s) <= a.formatter.depthLimit() {
+		return s, nil
+	}
+	e := jsonEncoder{limit: a.formatter.depthLimit()}
+	e.encode(v, a.depth, a.ancestors)
+	return e.b.String(), nil
+}
+
+// addressable returns a pointer to a copy of the struct, so encoding/json calls
+// the MarshalJSON methods with pointer receivers of the struct and its fields,
+// which it ignores on values that aren't addressable.
+func addressable(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Struct {
+		return v
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+// jsonDepth returns how deep the arrays and objects of the JSON document s
+// nest.
+func jsonDepth(s string) int {
+	depth, deepest := 0, 0
+	inString := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{' || c == '[':
+			depth++
+			deepest = max(deepest, depth)
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return deepest
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// jsonEncoder encodes values like encoding/json does, but never fails: map
+// keys are converted to strings with fmt, values without a JSON encoding become
+// markers, and so do the pointers, maps and slices referring back to their
+// ancestors and the values nested deeper than limit.
+type jsonEncoder struct {
+	b     strings.Builder
+	limit int
+}
+
+func (e *jsonEncoder) encode(v reflect.Value, depth int, ancestors *ancestor) {
+	if !v.IsValid() || (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		e.b.WriteString("null")
+		return
+	}
+	if v.Kind() == reflect.Interface {
+		e.encode(v.Elem(), depth, ancestors)
+		return
+	}
+	if depth > e.limit {
+		e.string(depthMarker)
+		return
+	}
+	if v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) {
+		e.leaf(v)
+		return
+	}
+	if v.CanAddr() && v.Kind() != reflect.Ptr {
+		if t := reflect.PointerTo(v.Type()); t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+			e.leaf(v.Addr())
+			return
+		}
+	}
+	arg := Argument{arg: v.Interface()}
+	if arg.isUnsupported() {
+		e.string(fmt.Sprintf("<unsupported %s>", v.Type()))
+		return
+	}
+	if id, ok := arg.identity(); ok {
+		if ancestors.contains(id) {
+			e.string(arg.cycleMarker())
+			return
+		}
+		ancestors = &ancestor{identity: id, parent: ancestors}
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		e.encode(v.Elem(), depth, ancestors)
+	case reflect.Map:
+		e.encodeMap(v, depth, ancestors)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			e.b.WriteString("null")
+			return
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			e.leaf(v)
+			return
+		}
+		e.b.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				e.b.WriteByte(',')
+			}
+			e.encode(v.Index(i), depth+1, ancestors)
+		}
+		e.b.WriteByte(']')
+	case reflect.Struct:
+		e.b.WriteByte('{')
+		e.encodeFields(v, depth, ancestors, false)
+		e.b.WriteByte('}')
+	default:
+		e.leaf(v)
+	}
+}
+
+// encodeMap encodes the pairs of the map sorted by key, and then by key type
+// when two keys print the same (e.g. 1 and "1").
+func (e *jsonEncoder) encodeMap(v reflect.Value, depth int, ancestors *ancestor) {
+	if v.IsNil() {
+		e.b.WriteString("null")
+		return
+	}
+	type pair struct {
+		key, keyType string
+		value        reflect.Value
+	}
+	var pairs []pair
+	iter := v.MapRange()
+	for iter.Next() {
+		key := iter.Key()
+		if key.Kind() == reflect.Interface {
+			key = key.Elem()
+		}
+		pairs = append(pairs, pair{fmt.Sprint(key.Interface()), key.Type().String(), iter.Value()})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].key != pairs[j].key {
+			return pairs[i].key < pairs[j].key
+		}
+		return pairs[i].keyType < pairs[j].keyType
+	})
+	e.b.WriteByte('{')
+	for i, p := range pairs {
+		if i > 0 {
+			e.b.WriteByte(',')
+		}
+		e.string(p.key)
+		e.b.WriteByte(':')
+		e.encode(p.value, depth+1, ancestors)
+	}
+	e.b.WriteByte('}')
+}
+
+// encodeFields encodes the exported fields of the struct following the
+// encoding/json rules for tags and omitempty. The fields of embedded structs
+// are promoted, and comma tells whether a field was already written.
+func (e *jsonEncoder) encodeFields(v reflect.Value, depth int, ancestors *ancestor, comma bool) bool {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		value := v.Field(i)
+		if field.Anonymous && name == "" {
+			embedded := ancestors
+			if value.Kind() == reflect.Ptr && value.Type().Elem().Kind() == reflect.Struct {
+				id := identity{typ: value.Type(), ptr: value.Pointer()}
+				if value.IsNil() || ancestors.contains(id) {
+					continue
+				}
+				value, embedded = value.Elem(), &ancestor{identity: id, parent: ancestors}
+			}
+			// Unexported embedded structs can't be read as a whole, but their
+			// exported fields can, so they are promoted as well.
+			if value.Kind() == reflect.Struct {
+				comma = e.encodeFields(value, depth, embedded, comma)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if strings.Contains(","+opts+",", ",omitempty,") && isEmptyJSON(value) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if comma {
+			e.b.WriteByte(',')
+		}
+		comma = true
+		e.string(name)
+		e.b.WriteByte(':')
+		e.encode(value, depth+1, ancestors)
+	}
+	return comma
+}
+
+// isEmptyJSON reports whether omitempty leaves the value out.
+func isEmptyJSON(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Struct:
+		return false
+	}
+	return v.IsZero()
+}
+
+// leaf encodes the value with encoding/json, or as the string fmt prints for
+// values it rejects, such as NaN.
+func (e *jsonEncoder) leaf(v reflect.Value) {
+	s, err := marshalJSON(v.Interface())
+	if err != nil {
+		s, _ = marshalJSON(fmt.Sprint(v.Interface()))
+	}
+	e.b.WriteString(s)
+}
+
+func (e *jsonEncoder) string(s string) {
+	encoded, _ := marshalJSON(s)
+	e.b.WriteString(encoded)
+}
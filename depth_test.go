@@ -0,0 +1,49 @@
+package queryf
+
+type category struct {
+	Name     string      `json:"name"`
+	Children []*category `json:"children,omitempty"`
+}
+
+func (suite *QueryfTestSuite) TestWithMaxDepth() {
+	f := New(WithMaxDepth(2))
+	suite.Equal(`SELECT '{{{"<max depth>"}}}'`, f.Format(`SELECT $1`, [][][]int{{{1}}}))
+	suite.Equal(`SELECT '{{1}}'`, f.Format(`SELECT $1`, [][]int{{1}}))
+
+	tree := &category{Name: "a", Children: []*category{{Name: "b", Children: []*category{{Name: "c"}}}}}
+	suite.Equal(`SELECT '{"name":"a","children":["<max depth>"]}'`, New(WithMaxDepth(1)).Format(`SELECT $1`, tree))
+	suite.Equal(`SELECT '{"a":{"b":{"c":"<max depth>"}}}'`, f.Format(`SELECT $1`, map[string]any{"a": map[string]any{"b": map[string]int{"c": 1}}}))
+	suite.Equal(`SELECT '{"name":"a","children":[{"name":"b","children":[{"name":"c"}]}]}'`, Format(`SELECT $1`, tree))
+
+	n := 1
+	p := &n
+	suite.Equal(`SELECT 1`, f.Format(`SELECT $1`, &p))
+	suite.Equal(`SELECT '{"<max depth>"}'`, New(WithMaxDepth(1)).Format(`SELECT $1`, []loopValuer{{}}))
+}
+
+func (suite *QueryfTestSuite) TestFormatDeepCycle() {
+	root := &category{Name: "root"}
+	root.Children = []*category{{Name: "child", Children: []*category{root}}}
+	suite.Equal(`SELECT '{"name":"root","children":[{"name":"child","children":["<cycle *queryf.category>"]}]}'`,
+		Format(`SELECT $1`, root))
+}
+
+type authorship struct {
+	CreatedBy string `json:"created_by"`
+	secret    string
+}
+
+type document struct {
+	authorship
+	*category
+	Title string `json:"title"`
+}
+
+func (suite *QueryfTestSuite) TestFormatUnexportedEmbedded() {
+	doc := document{authorship: authorship{CreatedBy: "john", secret: "x"}, category: &category{Name: "a"}, Title: "t"}
+	suite.Equal(`SELECT '{"created_by":"john","name":"a","title":"t"}'`, Format(`SELECT $1`, doc))
+	suite.Equal(`SELECT '{"created_by":"john","title":"t"}'`, Format(`SELECT $1`, document{authorship: authorship{CreatedBy: "john"}, Title: "t"}))
+	// Map values aren't addressable, nor are the structs encoded in them.
+	suite.Equal(`SELECT '{"deep":[[["<max depth>"]]],"doc":{"created_by":"john","name":"a","title":"t"}}'`,
+		New(WithMaxDepth(3)).Format(`SELECT $1`, map[string]any{"doc": doc, "deep": [][][]int{{{1}}}}))
+}
@@ -0,0 +1,408 @@
+package queryf
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Dialect controls the SQL syntax of the formatted query: the placeholders it
+// binds and how literals are written.
+type Dialect interface {
+	// PlaceholderStyle returns the placeholder syntax of the dialect.
+	PlaceholderStyle() PlaceholderStyle
+	// QuoteString returns s as a string literal, escaping it as needed.
+	QuoteString(s string) string
+	// FormatBool returns the boolean literal for b.
+	FormatBool(b bool) string
+	// FormatBytes returns the binary literal for b.
+	FormatBytes(b []byte) string
+	// FormatTime returns the timestamp literal for t.
+	FormatTime(t time.Time) string
+}
+
+// BackslashEscapes is implemented by dialects whose string literals have
+// backslash escapes, e.g. 'it\'s', so placeholders and literals are told apart
+// accordingly.
+type BackslashEscapes interface {
+	// BackslashEscapes reports whether backslashes escape the next character
+	// of string literals.
+	BackslashEscapes() bool
+}
+
+// ArrayLiteral is implemented by dialects writing slices as array literals of
+// their own instead of Postgres array literals.
+type ArrayLiteral interface {
+	// FormatArray returns the array literal of the formatted elements.
+	FormatArray(elems []string) string
+}
+
+// StructLiteral is implemented by dialects writing structs as struct literals
+// instead of JSON.
+type StructLiteral interface {
+	// StructTag returns the key of the struct tag naming the fields. Fields
+	// without the tag are named by their Go name, and fields tagged "-" are
+	// skipped.
+	StructTag() string
+	// FormatStruct returns the struct literal of the formatted field values,
+	// named by names.
+	FormatStruct(names, values []string) string
+}
+
+// MapLiteral is implemented by dialects writing maps as map literals instead
+// of JSON, and maps of struct{} as set literals.
+type MapLiteral interface {
+	// FormatMap returns the map literal of the formatted keys and values,
+	// sorted by key.
+	FormatMap(keys, values []string) string
+	// FormatSet returns the set literal of the formatted elements, sorted.
+	FormatSet(elems []string) string
+}
+
+// UUIDLiteral is implemented by dialects with uuid literals, instead of uuids
+// written as strings.
+type UUIDLiteral interface {
+	// FormatUUID returns the uuid literal for the hyphenated uuid.
+	FormatUUID(uuid string) string
+}
+
+var (
+	// Postgres formats $N queries for Postgres. This is the default dialect.
+	Postgres Dialect = postgres{}
+	// MySQL formats ? queries for MySQL and MariaDB.
+	MySQL Dialect = mysql{}
+	// SQLite formats ? queries for SQLite.
+	SQLite Dialect = sqlite{}
+	// SQLServer formats @p1, @p2, etc. queries for Microsoft SQL Server, as
+	// sent by go-mssqldb.
+	SQLServer Dialect = sqlServer{}
+	// Oracle formats :1, :2, etc. queries for Oracle, e.g. of godror. Named
+	// :name binds are formatted by FormatNamed.
+	Oracle Dialect = oracle{}
+	// ClickHouse formats ? queries for ClickHouse, with arrays as [1, 2, 3].
+	ClickHouse Dialect = clickHouse{}
+	// BigQuery formats ? queries for BigQuery, with arrays as [1, 2, 3] and
+	// structs as STRUCT literals. Named @name parameters are formatted by
+	// FormatNamed.
+	BigQuery Dialect = bigQuery{}
+	// CQL formats ? queries for Cassandra and ScyllaDB, with slices as lists,
+	// maps as maps and maps of struct{} as sets.
+	CQL Dialect = cql{}
+)
+
+// WithDialect sets the dialect of the formatted queries. Options such as
+// WithPlaceholderStyle, WithQuoter and WithTimeFormat take precedence over the
+// dialect. Defaults to Postgres.
+func WithDialect(dialect Dialect) Option {
+	return func(f *Formatter) {
+		f.dialect = dialect
+	}
+}
+
+var (
+	quoteEscaper        = strings.NewReplacer(`'`, `''`)
+	quoteUnescaper      = strings.NewReplacer(`''`, `'`)
+	arrayElementEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+// unquote returns the value of a '...', E'...' or U&'...' string literal, or
+// false if s isn't one.
+func unquote(s string) (string, bool) {
+	if len(s) > 3 && strings.HasPrefix(s, "U&'") && s[len(s)-1] == '\'' {
+		return unescapeUnicodeString(s[3 : len(s)-1]), true
+	}
+	if len(s) > 2 && s[0] == 'E' && s[1] == '\'' && s[len(s)-1] == '\'' {
+		return unescapeString(s[2 : len(s)-1]), true
+	}
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", false
+	}
+	return quoteUnescaper.Replace(s[1 : len(s)-1]), true
+}
+
+// uncast returns the string literal s without its trailing Postgres cast, e.g.
+// 'NaN' for 'NaN'::float8.
+func uncast(s string) string {
+	if i := strings.LastIndex(s, "'::"); i > 0 && !strings.Contains(s[i+1:], "'") {
+		return s[:i+1]
+	}
+	return s
+}
+
+// cast appends a Postgres cast to typ to the literal. Other dialects don't
+// support the :: syntax, so the literal is returned as is.
+func (f *Formatter) cast(literal, typ string) string {
+	if f.dialect != Postgres {
+		return literal
+	}
+	return literal + "::" + typ
+}
+
+type postgres struct{}
+
+func (postgres) PlaceholderStyle() PlaceholderStyle {
+	return Dollar
+}
+
+func (postgres) QuoteString(s string) string {
+	return "'" + quoteEscaper.Replace(s) + "'"
+}
+
+func (postgres) FormatBool(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+func (p postgres) FormatBytes(b []byte) string {
+	return p.QuoteString(`\x` + hex.EncodeToString(b))
+}
+
+func (p postgres) FormatTime(t time.Time) string {
+	return p.QuoteString(t.Format(time.RFC3339Nano))
+}
+
+var mysqlEscaper = strings.NewReplacer(`'`, `''`, `\`, `\\`)
+
+type mysql struct{}
+
+func (mysql) PlaceholderStyle() PlaceholderStyle {
+	return Question
+}
+
+func (mysql) QuoteString(s string) string {
+	return "'" + mysqlEscaper.Replace(s) + "'"
+}
+
+func (mysql) BackslashEscapes() bool {
+	return true
+}
+
+func (mysql) FormatBool(b bool) string {
+	return formatBoolNumber(b)
+}
+
+func (mysql) FormatBytes(b []byte) string {
+	return "X'" + hex.EncodeToString(b) + "'"
+}
+
+func (m mysql) FormatTime(t time.Time) string {
+	return m.QuoteString(t.Format("2006-01-02 15:04:05.999999"))
+}
+
+type sqlite struct{}
+
+func (sqlite) PlaceholderStyle() PlaceholderStyle {
+	return Question
+}
+
+func (sqlite) QuoteString(s string) string {
+	return "'" + quoteEscaper.Replace(s) + "'"
+}
+
+func (sqlite) FormatBool(b bool) string {
+	return formatBoolNumber(b)
+}
+
+func (sqlite) FormatBytes(b []byte) string {
+	return "X'" + hex.EncodeToString(b) + "'"
+}
+
+func (s sqlite) FormatTime(t time.Time) string {
+	return s.QuoteString(t.Format("2006-01-02 15:04:05.999999999-07:00"))
+}
+
+type sqlServer struct{}
+
+func (sqlServer) PlaceholderStyle() PlaceholderStyle {
+	return AtP
+}
+
+func (sqlServer) QuoteString(s string) string {
+	return "N'" + quoteEscaper.Replace(s) + "'"
+}
+
+func (sqlServer) FormatBool(b bool) string {
+	return formatBoolNumber(b)
+}
+
+func (sqlServer) FormatBytes(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+func (s sqlServer) FormatTime(t time.Time) string {
+	return s.QuoteString(t.Format("2006-01-02 15:04:05.9999999 -07:00"))
+}
+
+type oracle struct{}
+
+func (oracle) PlaceholderStyle() PlaceholderStyle {
+	return Colon
+}
+
+func (oracle) QuoteString(s string) string {
+	return "'" + quoteEscaper.Replace(s) + "'"
+}
+
+func (oracle) FormatBool(b bool) string {
+	return formatBoolNumber(b)
+}
+
+func (oracle) FormatBytes(b []byte) string {
+	return "HEXTORAW('" + hex.EncodeToString(b) + "')"
+}
+
+func (o oracle) FormatTime(t time.Time) string {
+	return "TIMESTAMP " + o.QuoteString(t.Format("2006-01-02 15:04:05.999999999 -07:00"))
+}
+
+func formatBoolNumber(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// backslashEscaper escapes string literals of the dialects with backslash
+// escape sequences, which can't hold raw line breaks either.
+var backslashEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+
+type clickHouse struct{}
+
+func (clickHouse) PlaceholderStyle() PlaceholderStyle {
+	return Question
+}
+
+func (clickHouse) QuoteString(s string) string {
+	return "'" + backslashEscaper.Replace(s) + "'"
+}
+
+func (clickHouse) BackslashEscapes() bool {
+	return true
+}
+
+func (clickHouse) FormatBool(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+func (clickHouse) FormatBytes(b []byte) string {
+	return "unhex('" + hex.EncodeToString(b) + "')"
+}
+
+// FormatTime returns a DateTime in UTC, or a DateTime64 with nanoseconds if t
+// has a fraction of second.
+func (c clickHouse) FormatTime(t time.Time) string {
+	t = t.UTC()
+	if t.Nanosecond() == 0 {
+		return "toDateTime(" + c.QuoteString(t.Format("2006-01-02 15:04:05")) + ", 'UTC')"
+	}
+	return "toDateTime64(" + c.QuoteString(t.Format("2006-01-02 15:04:05.000000000")) + ", 9, 'UTC')"
+}
+
+func (clickHouse) FormatArray(elems []string) string {
+	return formatBracketArray(elems)
+}
+
+type bigQuery struct{}
+
+func (bigQuery) PlaceholderStyle() PlaceholderStyle {
+	return Question
+}
+
+func (bigQuery) QuoteString(s string) string {
+	return "'" + backslashEscaper.Replace(s) + "'"
+}
+
+func (bigQuery) BackslashEscapes() bool {
+	return true
+}
+
+func (bigQuery) FormatBool(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+func (bigQuery) FormatBytes(b []byte) string {
+	return "FROM_HEX('" + hex.EncodeToString(b) + "')"
+}
+
+func (q bigQuery) FormatTime(t time.Time) string {
+	return "TIMESTAMP " + q.QuoteString(t.Format("2006-01-02 15:04:05.999999-07:00"))
+}
+
+func (bigQuery) FormatArray(elems []string) string {
+	return formatBracketArray(elems)
+}
+
+func (bigQuery) StructTag() string {
+	return "bigquery"
+}
+
+// FormatStruct returns a STRUCT literal, e.g. STRUCT(1 AS id, 'John' AS name).
+func (bigQuery) FormatStruct(names, values []string) string {
+	fields := make([]string, len(names))
+	for i, name := range names {
+		fields[i] = values[i] + " AS " + name
+	}
+	return "STRUCT(" + strings.Join(fields, ", ") + ")"
+}
+
+type cql struct{}
+
+func (cql) PlaceholderStyle() PlaceholderStyle {
+	return Question
+}
+
+func (cql) QuoteString(s string) string {
+	return "'" + quoteEscaper.Replace(s) + "'"
+}
+
+func (cql) FormatBool(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+func (cql) FormatBytes(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+func (c cql) FormatTime(t time.Time) string {
+	return c.QuoteString(t.Format("2006-01-02 15:04:05.000-0700"))
+}
+
+// FormatArray returns a list literal, e.g. ['a', 'b'].
+func (cql) FormatArray(elems []string) string {
+	return formatBracketArray(elems)
+}
+
+// FormatMap returns a map literal, e.g. {'k': 'v'}.
+func (cql) FormatMap(keys, values []string) string {
+	entries := make([]string, len(keys))
+	for i, key := range keys {
+		entries[i] = key + ": " + values[i]
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+// FormatSet returns a set literal, e.g. {1, 2}.
+func (cql) FormatSet(elems []string) string {
+	return "{" + strings.Join(elems, ", ") + "}"
+}
+
+// FormatUUID returns the uuid unquoted, since quoting turns it into a string.
+func (cql) FormatUUID(uuid string) string {
+	return uuid
+}
+
+// formatBracketArray returns the elements as an array literal, e.g. [1, 2, 3].
+func formatBracketArray(elems []string) string {
+	return "[" + strings.Join(elems, ", ") + "]"
+}
@@ -0,0 +1,137 @@
+package queryf
+
+import (
+	"strings"
+	"time"
+)
+
+func (suite *QueryfTestSuite) TestDialects() {
+	t := time.Date(2022, 2, 10, 13, 45, 0, 0, time.UTC)
+	args := []any{"it's", true, []byte{0xde, 0xad}, t}
+
+	suite.Equal(`SELECT 'it''s', true, '\xdead', '2022-02-10T13:45:00Z'`,
+		New(WithDialect(Postgres)).Format(`SELECT $1, $2, $3, $4`, args...))
+	suite.Equal(`SELECT 'it''s', 1, X'dead', '2022-02-10 13:45:00'`,
+		New(WithDialect(MySQL)).Format(`SELECT ?, ?, ?, ?`, args...))
+	suite.Equal(`SELECT 'it''s', 1, X'dead', '2022-02-10 13:45:00+00:00'`,
+		New(WithDialect(SQLite)).Format(`SELECT ?, ?, ?, ?`, args...))
+	suite.Equal(`SELECT N'it''s', 1, 0xdead, N'2022-02-10 13:45:00 +00:00'`,
+		New(WithDialect(SQLServer)).Format(`SELECT @p1, @p2, @p3, @p4`, args...))
+	suite.Equal(`SELECT N'it''s', @price, @@ROWCOUNT`, New(WithDialect(SQLServer)).Format(`SELECT @p1, @price, @@ROWCOUNT`, args...))
+	suite.Equal(`SELECT 'it''s', 1, HEXTORAW('dead'), TIMESTAMP '2022-02-10 13:45:00 +00:00'`,
+		New(WithDialect(Oracle)).Format(`SELECT :1, :2, :3, :4`, args...))
+	suite.Equal(`SELECT 'it''s', :name, 'it''s'`, New(WithDialect(Oracle)).Format(`SELECT :1, :name, :1`, args...))
+
+	suite.Equal(`SELECT 'a\\b'`, New(WithDialect(MySQL)).Format(`SELECT ?`, `a\b`))
+}
+
+func (suite *QueryfTestSuite) TestDialectOverrides() {
+	f := New(WithDialect(MySQL), WithPlaceholderStyle(Dollar), WithTimeFormat("2006-01-02"))
+	suite.Equal(`SELECT '2022-02-10', 0`, f.Format(`SELECT $1, $2`, time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC), false))
+}
+
+type bigQueryUser struct {
+	ID     int    `bigquery:"id"`
+	Name   string `bigquery:"name"`
+	Secret string `bigquery:"-"`
+	Tags   []string
+}
+
+func (suite *QueryfTestSuite) TestAnalyticsDialects() {
+	t := time.Date(2022, 2, 10, 13, 45, 0, 0, time.FixedZone("BRT", -3*60*60))
+	args := []any{"it's a\\b", true, []byte{0xde, 0xad}, t, []int{1, 2, 3}}
+
+	clickHouse := New(WithDialect(ClickHouse))
+	suite.Equal(`SELECT 'it\'s a\\b', true, unhex('dead'), toDateTime('2022-02-10 16:45:00', 'UTC'), [1, 2, 3]`,
+		clickHouse.Format(`SELECT ?, ?, ?, ?, ?`, args...))
+	suite.Equal(`SELECT toDateTime64('2022-02-10 16:45:00.500000000', 9, 'UTC'), ['a', NULL], [[1], []]`,
+		clickHouse.Format(`SELECT ?, ?, ?`, t.Add(500*time.Millisecond), []*string{ptrTo("a"), nil}, [][]int{{1}, {}}))
+	suite.Equal(`SELECT [1, 2](+1 elements)`, clickHouse.With(WithMaxSliceElems(2)).Format(`SELECT ?`, []int{1, 2, 3}))
+
+	bigQuery := New(WithDialect(BigQuery))
+	suite.Equal(`SELECT 'it\'s a\\b', true, FROM_HEX('dead'), TIMESTAMP '2022-02-10 13:45:00-03:00', [1, 2, 3]`,
+		bigQuery.Format(`SELECT ?, ?, ?, ?, ?`, args...))
+	suite.Equal(`SELECT STRUCT(1 AS id, 'John' AS name, ['a'] AS Tags), 'line\nbreak'`,
+		bigQuery.Format(`SELECT ?, ?`, bigQueryUser{ID: 1, Name: "John", Secret: "x", Tags: []string{"a"}}, "line\nbreak"))
+	suite.Equal(`SELECT * FROM t WHERE id = 1`, bigQuery.FormatNamed(`SELECT * FROM t WHERE id = @id`, map[string]any{"id": 1}))
+	suite.Equal("SELECT `id`\nFROM `users`", New(WithDialect(BigQuery), WithQuoteIdentifiers()).Pretty(`SELECT id FROM users`))
+}
+
+func (suite *QueryfTestSuite) TestCQLDialect() {
+	t := time.Date(2022, 2, 10, 13, 45, 0, 0, time.UTC)
+	cql := New(WithDialect(CQL))
+	suite.Equal(`SELECT 'it''s', true, 0xdead, '2022-02-10 13:45:00.000+0000', 123e4567-e89b-12d3-a456-426614174000`,
+		cql.Format(`SELECT ?, ?, ?, ?, ?`, "it's", true, []byte{0xde, 0xad}, t,
+			[16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}))
+	suite.Equal(`UPDATE users SET tags = ['a', 'b'], ids = {2, 10}, attrs = {'k': 'v', 'l': 'w'}, scores = {9: [1], 10: []}`,
+		cql.Format(`UPDATE users SET tags = ?, ids = ?, attrs = ?, scores = ?`,
+			[]string{"a", "b"},
+			map[int]struct{}{10: {}, 2: {}},
+			map[string]string{"l": "w", "k": "v"},
+			map[int][]int{10: {}, 9: {1}}))
+	suite.Equal(`SELECT {}`, cql.Format(`SELECT ?`, map[string]int{}))
+}
+
+func (suite *QueryfTestSuite) TestAnalyticsDialectsRoundTrip() {
+	query := "SELECT *\nFROM t WHERE a = ?\n  AND b = ?"
+	for _, dialect := range []Dialect{ClickHouse, BigQuery} {
+		f := New(WithDialect(dialect))
+		formatted := f.Format(query, `it's -- ?`, 1)
+		suite.Equal("SELECT *\nFROM t WHERE a = 'it\\'s -- ?'\n  AND b = 1", formatted)
+
+		suite.Equal(`SELECT * FROM t WHERE a = 'it\'s -- ?' AND b = 1`, f.Compact(formatted))
+		suite.Equal(`select * from t where a = ? and b = ?`, f.Fingerprint(formatted))
+		suite.Equal("SELECT *\nFROM t\nWHERE a = 'it\\'s -- ?'\n  AND b = 1", f.Pretty(formatted))
+		suite.Empty(f.Diff(formatted, f.Compact(formatted)))
+
+		parameterized, args, err := f.Parameterize(formatted)
+		suite.Nil(err)
+		suite.Equal(query, parameterized)
+		suite.Equal([]any{`it's -- ?`, int64(1)}, args)
+	}
+}
+
+// rowDialect is Postgres with ARRAY and ROW constructors, as a custom dialect
+// opting in to the literal interfaces.
+type rowDialect struct {
+	Dialect
+}
+
+func (rowDialect) FormatArray(elems []string) string {
+	return "ARRAY[" + strings.Join(elems, ", ") + "]"
+}
+
+func (rowDialect) StructTag() string {
+	return "db"
+}
+
+func (rowDialect) FormatStruct(_, values []string) string {
+	return "ROW(" + strings.Join(values, ", ") + ")"
+}
+
+func (rowDialect) FormatMap(keys, values []string) string {
+	return "MAP(ARRAY[" + strings.Join(keys, ", ") + "], ARRAY[" + strings.Join(values, ", ") + "])"
+}
+
+func (d rowDialect) FormatSet(elems []string) string {
+	return d.FormatArray(elems)
+}
+
+func (rowDialect) FormatUUID(uuid string) string {
+	return "'" + uuid + "'::uuid"
+}
+
+func (suite *QueryfTestSuite) TestCustomLiteralDialect() {
+	f := New(WithDialect(rowDialect{Postgres}))
+	suite.Equal(`SELECT ARRAY[1, 2], ROW(1, 'John')`, f.Format(`SELECT $1, $2`, []int{1, 2}, struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}{1, "John"}))
+	suite.Equal(`SELECT MAP(ARRAY['a', 'b'], ARRAY[1, 2]), ARRAY[1, 2], '00000000-0000-0000-0000-000000000001'::uuid`,
+		f.Format(`SELECT $1, $2, $3`, map[string]int{"b": 2, "a": 1}, map[int]struct{}{2: {}, 1: {}},
+			[16]byte{15: 1}))
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
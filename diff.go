@@ -0,0 +1,94 @@
+package queryf
+
+import "strings"
+
+// Diff compares two queries, e.g. the query an ORM was expected to build and
+// the query it built, and returns their differences line by line, or "" if
+// they are the same. Both queries are laid out like Pretty, with their
+// keywords in upper case unless WithKeywordCase is given and spaces around
+// their operators, so whitespace and keyword case don't count as differences.
+// Lines only in a start with "- ", lines only in b with "+ " and common lines
+// with two spaces.
+//
+// Example:
+//
+//	fmt.Println(queryf.Diff("select * from users where id = 1", "SELECT * FROM users WHERE id = 2"))
+//	// Output:
+//	//   SELECT *
+//	//   FROM users
+//	// - WHERE id = 1
+//	// + WHERE id = 2
+func Diff(a, b string, opts ...Option) string {
+	return Default().With(opts...).Diff(a, b)
+}
+
+// Diff compares two queries laid out with the Formatter configuration. See the
+// package level Diff for details.
+func (f *Formatter) Diff(a, b string) string {
+	plain := *f
+	plain.cache = nil
+	plain.keywordColor = false
+	if plain.keywordCase == "" || plain.keywordCase == Preserve {
+		plain.keywordCase = Upper
+	}
+	backslash := plain.syntax().backslash
+	x := strings.Split(plain.layout(normalizeSpaces(lexemes(a, backslash))), "\n")
+	y := strings.Split(plain.layout(normalizeSpaces(lexemes(b, backslash))), "\n")
+	// common[i][j] is the length of the longest common subsequence of x[i:]
+	// and y[j:].
+	common := make([][]int, len(x)+1)
+	for i := range common {
+		common[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+	if common[0][0] == len(x) && len(x) == len(y) {
+		return ""
+	}
+	var lines []string
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			lines = append(lines, "  "+x[i])
+			i++
+			j++
+		case j == len(y) || i < len(x) && common[i+1][j] >= common[i][j+1]:
+			lines = append(lines, "- "+x[i])
+			i++
+		default:
+			lines = append(lines, "+ "+y[j])
+			j++
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// normalizeSpaces sets the spaces between the lexemes the same way whatever
+// the query had: around operators and between words, but not around dots,
+// casts, brackets and $ placeholders. Parentheses and literals keep the space
+// they had after a name, which tells count(*) from users (id) and E'...' from
+// E '...'.
+func normalizeSpaces(ls []lexeme) []lexeme {
+	for i := 1; i < len(ls); i++ {
+		prev, l := ls[i-1], &ls[i]
+		switch {
+		case prev.text == "." || prev.text == "::" || prev.text == "[" || prev.text == "$":
+			l.space = false
+		case l.text == "." || l.text == "::" || l.text == "[" || l.text == "]" || l.text == ";":
+			l.space = false
+		case l.text == "(" && prev.kind == lexemeWord && !keywords[strings.ToLower(prev.text)]:
+		case l.kind == lexemeLiteral && prev.kind == lexemeWord:
+		default:
+			l.space = true
+		}
+	}
+	return ls
+}
@@ -0,0 +1,24 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestDiff() {
+	suite.Equal(`  SELECT *
+  FROM users
+- WHERE id = 1
++ WHERE id = 2`, Diff("select * from users where id = 1", "SELECT *\n  FROM users\n  WHERE id = 2"))
+
+	suite.Equal(`  SELECT id, name
+  FROM users
+  WHERE active = TRUE
+- ORDER BY name
++   AND deleted_at IS NULL
++ ORDER BY id
+  LIMIT 10`, Diff(
+		"SELECT id, name FROM users WHERE active = true ORDER BY name LIMIT 10",
+		"SELECT id, name FROM users WHERE active = true AND deleted_at IS NULL ORDER BY id LIMIT 10"))
+
+	suite.Equal("", Diff("select  *\nfrom users", "SELECT * FROM users"))
+	suite.Equal("- select *\n+ select id", Diff("SELECT *", "SELECT id", WithKeywordCase(Lower)))
+	suite.Equal("", Diff("SELECT count(*) FROM users WHERE id=$1 AND tags && ARRAY['a']", "select count(*) from users where id = $1 and tags&&ARRAY ['a']"))
+	suite.Equal("", Diff("SELECT u.id::text FROM users u WHERE id IN(1,2)", "SELECT u . id :: text FROM users u WHERE id IN ( 1 , 2 )"))
+	suite.Equal("- SELECT E'a'\n+ SELECT E 'a'", Diff("SELECT E'a'", "SELECT E 'a'"))
+}
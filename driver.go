@@ -0,0 +1,64 @@
+package queryf
+
+import "database/sql/driver"
+
+// FormatDriverValues formats the query with the arguments in the form drivers
+// receive them, e.g. in driver.Execer implementations and database/sql
+// instrumentation wrapping a driver.
+//
+// Example:
+//
+//	func (c *conn) Exec(query string, args []driver.Value) (driver.Result, error) {
+//		log.Println(queryf.FormatDriverValues(query, args))
+//		return c.Conn.Exec(query, args)
+//	}
+func FormatDriverValues(query string, args []driver.Value) string {
+	return Default().FormatDriverValues(query, args)
+}
+
+// FormatDriverValues formats the query with the driver arguments using the
+// Formatter configuration. See the package level FormatDriverValues for
+// details.
+func (f *Formatter) FormatDriverValues(query string, args []driver.Value) string {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	return f.Format(query, values...)
+}
+
+// FormatNamedValues formats the query with the arguments in the form
+// driver.ExecerContext and driver.QueryerContext implementations receive them.
+// The values are bound by their Ordinal, or by their Name to :name and @name
+// placeholders when every value has a name.
+//
+// Example:
+//
+//	func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+//		log.Println(queryf.FormatNamedValues(query, args))
+//		return c.Conn.ExecContext(ctx, query, args)
+//	}
+func FormatNamedValues(query string, args []driver.NamedValue) string {
+	return Default().FormatNamedValues(query, args)
+}
+
+// FormatNamedValues formats the query with the named driver arguments using
+// the Formatter configuration. See the package level FormatNamedValues for
+// details.
+func (f *Formatter) FormatNamedValues(query string, args []driver.NamedValue) string {
+	values := make([]any, len(args))
+	named := make(map[string]any, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			named[arg.Name] = arg.Value
+		}
+		if arg.Ordinal > 0 && arg.Ordinal <= len(args) {
+			i = arg.Ordinal - 1
+		}
+		values[i] = arg.Value
+	}
+	if len(args) > 0 && len(named) == len(args) {
+		return f.FormatNamed(query, named)
+	}
+	return f.Format(query, values...)
+}
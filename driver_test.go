@@ -0,0 +1,32 @@
+package queryf
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+func (suite *QueryfTestSuite) TestFormatDriverValues() {
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND name = 'John' AND avatar = '\x0102'`,
+		FormatDriverValues(`SELECT * FROM users WHERE id = $1 AND name = $2 AND avatar = $3`,
+			[]driver.Value{int64(1), "John", []byte{1, 2}}))
+	suite.Equal(`SELECT 1`, FormatDriverValues(`SELECT 1`, nil))
+}
+
+func (suite *QueryfTestSuite) TestFormatNamedValues() {
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND name = 'John'`,
+		FormatNamedValues(`SELECT * FROM users WHERE id = $1 AND name = $2`,
+			[]driver.NamedValue{{Ordinal: 2, Value: "John"}, {Ordinal: 1, Value: int64(1)}}))
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND name = 'John'`,
+		FormatNamedValues(`SELECT * FROM users WHERE id = @id AND name = @name`,
+			[]driver.NamedValue{{Name: "id", Ordinal: 1, Value: int64(1)}, {Name: "name", Ordinal: 2, Value: "John"}}))
+
+	f := New(WithPlaceholderStyle(Question), WithRedactedParams(2))
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND password = '[REDACTED]'`,
+		f.FormatNamedValues(`SELECT * FROM users WHERE id = ? AND password = ?`,
+			[]driver.NamedValue{{Ordinal: 1, Value: int64(1)}, {Ordinal: 2, Value: "secret"}}))
+}
+
+func (suite *QueryfTestSuite) TestRawBytes() {
+	suite.Equal(`SELECT '\x0102', NULL`, Format(`SELECT $1, $2`, sql.RawBytes{1, 2}, sql.RawBytes(nil)))
+	suite.Equal(Bytes, NewArgument(sql.RawBytes{1}).GetType())
+}
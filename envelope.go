@@ -0,0 +1,55 @@
+package queryf
+
+import "encoding/json"
+
+// envelope is the document written by FormatJSON.
+type envelope struct {
+	Query        string        `json:"query"`
+	Interpolated string        `json:"interpolated"`
+	Args         []envelopeArg `json:"args"`
+}
+
+type envelopeArg struct {
+	Index   int           `json:"index"`
+	Type    ParameterType `json:"type"`
+	Value   any           `json:"value"`
+	Literal string        `json:"literal"`
+}
+
+// FormatJSON returns a JSON document with the query, the query formatted like
+// Format and each argument with its position, type, value and literal, for
+// tools that process debug logs. Values that can't be encoded as JSON, like
+// channels, are replaced by their literal.
+//
+// Example:
+//
+//	b, _ := queryf.FormatJSON("SELECT * FROM users WHERE name = $1", "John")
+//	fmt.Println(string(b))
+//	// Output: {"query":"SELECT * FROM users WHERE name = $1","interpolated":"SELECT * FROM users WHERE name = 'John'","args":[{"index":1,"type":"string","value":"John","literal":"'John'"}]}
+func FormatJSON(query string, args ...any) ([]byte, error) {
+	return Default().FormatJSON(query, args...)
+}
+
+// FormatJSON returns a JSON document describing the query with the Formatter
+// configuration. See the package level FormatJSON for details.
+func (f *Formatter) FormatJSON(query string, args ...any) ([]byte, error) {
+	plain := *f
+	plain.cache = nil
+	plain.color, plain.keywordColor = false, false
+	e := envelope{
+		Query:        query,
+		Interpolated: plain.Format(query, args...),
+		Args:         make([]envelopeArg, len(args)),
+	}
+	for i, arg := range args {
+		literal := plain.literal(i+1, arg)
+		value := arg
+		if f.redacted(i+1, arg) {
+			value = redactedValue
+		} else if _, err := json.Marshal(arg); err != nil {
+			value = literal
+		}
+		e.Args[i] = envelopeArg{Index: i + 1, Type: f.NewArgument(arg).GetType(), Value: value, Literal: literal}
+	}
+	return json.Marshal(e)
+}
@@ -0,0 +1,32 @@
+package queryf
+
+import "math"
+
+func (suite *QueryfTestSuite) TestFormatJSON() {
+	b, err := FormatJSON(`SELECT * FROM users WHERE name = $1 AND id = $2 AND deleted_at = $3`, "John", 1, nil)
+	suite.Nil(err)
+	suite.JSONEq(`{
+		"query": "SELECT * FROM users WHERE name = $1 AND id = $2 AND deleted_at = $3",
+		"interpolated": "SELECT * FROM users WHERE name = 'John' AND id = 1 AND deleted_at = NULL",
+		"args": [
+			{"index": 1, "type": "string", "value": "John", "literal": "'John'"},
+			{"index": 2, "type": "integer", "value": 1, "literal": "1"},
+			{"index": 3, "type": "null", "value": null, "literal": "NULL"}
+		]
+	}`, string(b))
+
+	b, err = New(WithRedactedParams(1), WithColor()).FormatJSON(`SELECT $1, $2`, "secret", math.NaN())
+	suite.Nil(err)
+	suite.JSONEq(`{
+		"query": "SELECT $1, $2",
+		"interpolated": "SELECT '[REDACTED]', 'NaN'::float8",
+		"args": [
+			{"index": 1, "type": "string", "value": "[REDACTED]", "literal": "'[REDACTED]'"},
+			{"index": 2, "type": "float", "value": "'NaN'::float8", "literal": "'NaN'::float8"}
+		]
+	}`, string(b))
+
+	b, err = FormatJSON(`SELECT 1`)
+	suite.Nil(err)
+	suite.JSONEq(`{"query": "SELECT 1", "interpolated": "SELECT 1", "args": []}`, string(b))
+}
@@ -0,0 +1,175 @@
+package queryf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WithEscapeStrings formats strings holding backslashes or control characters
+// as Postgres escape string literals, e.g. E'a\nb', so psql reads them as the
+// driver sent them. Other strings and dialects are not affected.
+func WithEscapeStrings() Option {
+	return func(f *Formatter) {
+		f.escapeStrings = true
+	}
+}
+
+// needsEscape reports whether s holds a backslash or a control character.
+func needsEscape(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' || s[i] < ' ' || s[i] == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeString returns s as an E'...' escape string literal.
+func escapeString(s string) string {
+	var b strings.Builder
+	b.WriteString("E'")
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\'':
+			b.WriteString(`''`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			if c < ' ' || c == 0x7f {
+				fmt.Fprintf(&b, `\x%02x`, c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// unescapeString returns the value of the body of an E'...' literal written by
+// escapeString.
+func unescapeString(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\'' && i+1 < len(s) && s[i+1] == '\'' {
+			b.WriteByte('\'')
+			i++
+			continue
+		}
+		if c != '\\' || i+1 == len(s) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'x':
+			n, err := strconv.ParseUint(s[i+1:min(i+3, len(s))], 16, 8)
+			if err != nil {
+				b.WriteByte('x')
+				continue
+			}
+			b.WriteByte(byte(n))
+			i += 2
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// WithUnicodeEscapes formats strings holding non-ASCII or non-printable
+// characters as Postgres Unicode escape literals, e.g. U&'ol\00e1', so the
+// query survives log pipelines that mangle them. Invalid UTF-8 is formatted as
+// U+FFFD. Other strings and dialects are not affected.
+func WithUnicodeEscapes() Option {
+	return func(f *Formatter) {
+		f.unicodeEscapes = true
+	}
+}
+
+// needsUnicodeEscape reports whether s holds a non-ASCII or a control
+// character.
+func needsUnicodeEscape(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < ' ' || s[i] >= 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// unicodeEscapeString returns s as a U&'...' Unicode escape literal.
+func unicodeEscapeString(s string) string {
+	var b strings.Builder
+	b.WriteString("U&'")
+	for _, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\'':
+			b.WriteString(`''`)
+		case r >= ' ' && r < 0x7f:
+			b.WriteRune(r)
+		case r > 0xffff:
+			fmt.Fprintf(&b, `\+%06x`, r)
+		default:
+			fmt.Fprintf(&b, `\%04x`, r)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// unescapeUnicodeString returns the value of the body of a U&'...' literal
+// written by unicodeEscapeString.
+func unescapeUnicodeString(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\'' && i+1 < len(s) && s[i+1] == '\'' {
+			b.WriteByte('\'')
+			i++
+			continue
+		}
+		if c != '\\' || i+1 == len(s) {
+			b.WriteByte(c)
+			continue
+		}
+		digits := 4
+		if s[i+1] == '+' {
+			digits = 6
+			i++
+		}
+		n, err := strconv.ParseUint(s[i+1:min(i+1+digits, len(s))], 16, 32)
+		if err != nil {
+			b.WriteByte(s[i+1])
+			i++
+			continue
+		}
+		b.WriteRune(rune(n))
+		i += digits
+	}
+	return b.String()
+}
@@ -0,0 +1,34 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestEscapeStrings() {
+	f := New(WithEscapeStrings())
+	suite.Equal(`SELECT E'a\nb\tc\r', E'C:\\dir', E'it''s\\', E'\x00\x1b\x7f'`, f.Format(`SELECT $1, $2, $3, $4`, "a\nb\tc\r", `C:\dir`, `it's\`, "\x00\x1b\x7f"))
+	suite.Equal(`SELECT 'it''s', 'olá'`, f.Format(`SELECT $1, $2`, "it's", "olá"))
+	suite.Equal(`SELECT '\xdead'`, f.Format(`SELECT $1`, []byte{0xde, 0xad}))
+	suite.Equal(`SELECT E'{"a\nb","\\"","x"}'`, f.Format(`SELECT $1`, []string{"a\nb", `"`, "x"}))
+	suite.Equal(`SELECT '{"a\\b"}'`, Format(`SELECT $1`, []string{`a\b`}))
+	suite.Equal("SELECT 'a\nb'", Format(`SELECT $1`, "a\nb"))
+	suite.Equal("SELECT 'a\nb'", New(WithEscapeStrings(), WithDialect(SQLite)).Format(`SELECT ?`, "a\nb"))
+
+	for _, s := range []string{"a\nb", `\x1`, `\`, "it's\x00", "\b\f\\x"} {
+		v, ok := unquote(escapeString(s))
+		suite.True(ok)
+		suite.Equal(s, v)
+	}
+}
+
+func (suite *QueryfTestSuite) TestUnicodeEscapes() {
+	f := New(WithUnicodeEscapes())
+	suite.Equal(`SELECT U&'ol\00e1', U&'\+01f600 it''s', U&'a\000ab\\'`, f.Format(`SELECT $1, $2, $3`, "olá", "😀 it's", "a\nb\\"))
+	suite.Equal(`SELECT 'plain', 'a\b'`, f.Format(`SELECT $1, $2`, "plain", `a\b`))
+	suite.Equal(`SELECT U&'\fffd'`, f.Format(`SELECT $1`, "\xff"))
+	suite.Equal(`SELECT U&'{"ol\00e1","\\\\"}'`, f.Format(`SELECT $1`, []string{"olá", `\`}))
+	suite.Equal(`SELECT E'a\\b', U&'\00e1\\'`, New(WithUnicodeEscapes(), WithEscapeStrings()).Format(`SELECT $1, $2`, `a\b`, `á\`))
+	suite.Equal(`SELECT 'olá'`, New(WithUnicodeEscapes(), WithDialect(MySQL)).Format(`SELECT ?`, "olá"))
+
+	for _, s := range []string{"olá", "😀\n", `\+0041`, "it's \\"} {
+		v, ok := unquote(unicodeEscapeString(s))
+		suite.True(ok)
+		suite.Equal(s, v)
+	}
+}
@@ -0,0 +1,75 @@
+package queryf
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrEmptySlice is returned by Expand when a slice argument has no elements,
+// since IN () is not valid SQL.
+var ErrEmptySlice = errors.New("queryf: empty slice can't be expanded")
+
+// Expand rewrites the query so each slice argument is bound to a list of
+// placeholders, one per element, and returns it with the flattened arguments,
+// like sqlx.In. []byte and driver.Valuer arguments, e.g. pq.Array, are not
+// expanded.
+//
+// Example:
+//
+//	query, args, _ := queryf.Expand("SELECT * FROM users WHERE id IN ($1) AND active = $2", []int{1, 2, 3}, true)
+//	fmt.Println(query)
+//	// Output: SELECT * FROM users WHERE id IN ($1, $2, $3) AND active = $4
+//	fmt.Println(queryf.Format(query, args...))
+//	// Output: SELECT * FROM users WHERE id IN (1, 2, 3) AND active = true
+func Expand(query string, args ...any) (string, []any, error) {
+	return Default().Expand(query, args...)
+}
+
+// Expand rewrites the query so each slice argument is bound to a list of
+// placeholders, using the Formatter placeholder style. See the package level
+// Expand for details.
+func (f *Formatter) Expand(query string, args ...any) (string, []any, error) {
+	if err := f.checkPlaceholders(query, args); err != nil {
+		return "", nil, err
+	}
+	var flattened []any
+	starts := make([]int, len(args))
+	counts := make([]int, len(args))
+	for i, arg := range args {
+		elems := expandArg(arg)
+		if len(elems) == 0 {
+			return "", nil, fmt.Errorf("%w at position %d", ErrEmptySlice, i+1)
+		}
+		starts[i], counts[i] = len(flattened)+1, len(elems)
+		flattened = append(flattened, elems...)
+	}
+
+	style := f.style()
+	// The query is still bound by the driver, so escaped ?? are kept.
+	query = replacePlaceholders(query, f.syntax(), false, func(p placeholder) (string, bool) {
+		i := p.index - 1
+		list := make([]string, counts[i])
+		for j := range list {
+			list[j] = placeholderText(style, starts[i]+j)
+		}
+		return strings.Join(list, ", "), true
+	})
+	return query, flattened, nil
+}
+
+// expandArg returns the elements of a slice argument, or the argument itself
+// if it shouldn't be expanded.
+func expandArg(arg any) []any {
+	rv := reflect.ValueOf(arg)
+	if _, ok := arg.(driver.Valuer); ok || rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return []any{arg}
+	}
+	elems := make([]any, rv.Len())
+	for i := range elems {
+		elems[i] = rv.Index(i).Interface()
+	}
+	return elems
+}
@@ -0,0 +1,30 @@
+package queryf
+
+import "github.com/lib/pq"
+
+func (suite *QueryfTestSuite) TestExpand() {
+	query, args, err := Expand(`SELECT * FROM t WHERE id IN ($1) AND a = $2 AND b IN ($3) OR id IN ($1)`, []int{1, 2, 3}, "x", []string{"y", "z"})
+	suite.Nil(err)
+	suite.Equal(`SELECT * FROM t WHERE id IN ($1, $2, $3) AND a = $4 AND b IN ($5, $6) OR id IN ($1, $2, $3)`, query)
+	suite.Equal([]any{1, 2, 3, "x", "y", "z"}, args)
+	suite.Equal(`SELECT * FROM t WHERE id IN (1, 2, 3) AND a = 'x' AND b IN ('y', 'z') OR id IN (1, 2, 3)`, Format(query, args...))
+
+	query, args, err = New(WithPlaceholderStyle(Question)).Expand(`SELECT * FROM t WHERE id IN (?) AND b = ?`, []int64{4, 5}, []byte("b"))
+	suite.Nil(err)
+	suite.Equal(`SELECT * FROM t WHERE id IN (?, ?) AND b = ?`, query)
+	suite.Equal([]any{int64(4), int64(5), []byte("b")}, args)
+
+	query, args, err = Expand(`SELECT * FROM t WHERE tags && $1`, pq.Array([]string{"a"}))
+	suite.Nil(err)
+	suite.Equal(`SELECT * FROM t WHERE tags && $1`, query)
+	suite.Len(args, 1)
+}
+
+func (suite *QueryfTestSuite) TestExpandErrors() {
+	_, _, err := Expand(`SELECT * FROM t WHERE a = $1 AND id IN ($2)`, 1, []int{})
+	suite.ErrorIs(err, ErrEmptySlice)
+	suite.EqualError(err, "queryf: empty slice can't be expanded at position 2")
+
+	_, _, err = Expand(`SELECT * FROM t WHERE id IN ($1, $2)`, []int{1})
+	suite.ErrorIs(err, ErrMissingArgument)
+}
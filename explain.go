@@ -0,0 +1,48 @@
+package queryf
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// explainPrefix is prepended to the query by Explain.
+const explainPrefix = "EXPLAIN (ANALYZE false, VERBOSE) "
+
+// Explain returns the Postgres plan of the query formatted with its arguments,
+// one line per row of the EXPLAIN output. ANALYZE is off, so the query itself
+// is never executed. The arguments are interpolated as FormatE does, and its
+// errors are returned before the database is queried.
+//
+// Example:
+//
+//	plan, err := queryf.Explain(ctx, db, "SELECT * FROM users WHERE id = $1", 1)
+//	fmt.Println(plan)
+//	// Output:
+//	// Index Scan using users_pkey on public.users  (cost=0.15..8.17 rows=1 width=72)
+//	//   Output: id, name
+//	//   Index Cond: (users.id = 1)
+func Explain(ctx context.Context, db *sql.DB, query string, args ...any) (string, error) {
+	formatted, err := FormatE(query, args...)
+	if err != nil {
+		return "", err
+	}
+	rows, err := db.QueryContext(ctx, explainPrefix+formatted)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var lines []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,25 @@
+package queryf
+
+import (
+	"context"
+	"errors"
+)
+
+func (suite *QueryfTestSuite) TestExplain() {
+	fake, db := newFakeDB("Index Scan using users_pkey on public.users", "  Index Cond: (users.id = 1)")
+	defer db.Close()
+
+	plan, err := Explain(context.Background(), db, `SELECT * FROM users WHERE id = $1`, 1)
+	suite.Nil(err)
+	suite.Equal("Index Scan using users_pkey on public.users\n  Index Cond: (users.id = 1)", plan)
+	suite.Equal([]string{`EXPLAIN (ANALYZE false, VERBOSE) SELECT * FROM users WHERE id = 1`}, fake.statements)
+	suite.Empty(fake.args[0])
+
+	_, err = Explain(context.Background(), db, `SELECT * FROM users WHERE id = $1`)
+	suite.ErrorIs(err, ErrMissingArgument)
+	suite.Len(fake.statements, 1)
+
+	fake.err = errors.New("syntax error")
+	_, err = Explain(context.Background(), db, `SELEC 1`)
+	suite.EqualError(err, "syntax error")
+}
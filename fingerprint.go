@@ -0,0 +1,78 @@
+package queryf
+
+import (
+	"regexp"
+	"strings"
+)
+
+// inList matches IN lists of placeholders in a fingerprint.
+var inList = regexp.MustCompile(`\bin \(\?(, \?)+\)`)
+
+// Fingerprint returns the query normalized for grouping: string and number
+// literals and placeholders become ?, comments are removed, whitespace is
+// collapsed, unquoted text is lowercased and IN lists are reduced to a single
+// element. Queries that only differ in their values have the same fingerprint.
+//
+// Example:
+//
+//	fmt.Println(queryf.Fingerprint("SELECT * FROM users WHERE id IN (1, 2, 3) AND name = 'John'"))
+//	// Output: select * from users where id in (?) and name = ?
+func Fingerprint(query string) string {
+	return Default().Fingerprint(query)
+}
+
+// Fingerprint returns the query normalized for grouping, using the Formatter
+// placeholder style. See the package level Fingerprint for details.
+func (f *Formatter) Fingerprint(query string) string {
+	w := &fingerprintWriter{}
+	t := newTokenizer(query, f.syntax())
+	for tok, ok := t.next(); ok; tok, ok = t.next() {
+		switch tok.kind {
+		case tokenText:
+			w.writeText(query, tok.start, tok.end)
+		case tokenComment:
+			w.space = true
+		case tokenQuotedIdentifier:
+			w.write(query[tok.start:tok.end])
+		default:
+			w.write("?")
+		}
+	}
+	return inList.ReplaceAllString(w.String(), "in (?)")
+}
+
+// fingerprintWriter builds a fingerprint, writing a single space where the
+// query had whitespace, except inside parentheses and before commas.
+type fingerprintWriter struct {
+	strings.Builder
+	space bool
+}
+
+func (w *fingerprintWriter) write(s string) {
+	if w.space && w.Len() > 0 && s[0] != ')' && s[0] != ',' && !strings.HasSuffix(w.String(), "(") {
+		w.WriteByte(' ')
+	}
+	w.space = false
+	w.WriteString(s)
+	if s == "," {
+		w.space = true
+	}
+}
+
+// writeText writes query[start:end] lowercased, with its numbers replaced by ?.
+func (w *fingerprintWriter) writeText(query string, start, end int) {
+	for i := start; i < end; i++ {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			w.space = true
+		case isDigit(c) && (i == 0 || !isNameChar(query[i-1]) && query[i-1] != '$'):
+			i = numberEnd(query[:end], i) - 1
+			w.write("?")
+		case c >= 'A' && c <= 'Z':
+			w.write(string(c + 'a' - 'A'))
+		default:
+			w.write(query[i : i+1])
+		}
+	}
+}
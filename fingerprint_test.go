@@ -0,0 +1,14 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestFingerprint() {
+	suite.Equal(`select * from users where id in (?) and name = ?`,
+		Fingerprint("SELECT *\n  FROM users -- all of them\n WHERE id IN (1, 2,3) AND name = 'John'"))
+	suite.Equal(Fingerprint(`SELECT * FROM t WHERE a = $1 AND b = 'x'`), Fingerprint("select  *  from t /* c */ where a = $2\n  and b = E'y'"))
+	suite.Equal(`select "Name", t1.a, ?::int from t1 where x = ? limit ?`, Fingerprint(`SELECT "Name", t1.a, $1::int FROM t1 WHERE x = 1.5 LIMIT 10`))
+	suite.Equal(`select ? as "Ñame", ? as ñame`, Fingerprint(`SELECT 1 AS "Ñame", 'á' AS ñame`))
+	suite.Equal(`select ?, ?`, Fingerprint(`SELECT $$body$$, $tag$x$tag$`))
+	suite.Equal(`insert into t (a, b) values (?, ?)`, Fingerprint(`INSERT INTO t ( a,b ) VALUES ( $1,$2 )`))
+	suite.Equal(`select ? from t where a in (?)`, New(WithPlaceholderStyle(Question)).Fingerprint(`SELECT ? FROM t WHERE a IN (?, ?)`))
+	suite.Equal(`select ?, ?, ?, e5 from t where x > ?`, Fingerprint(`SELECT 1e5, 1.5E-3, 2e+10, e5 FROM t WHERE x > 3`))
+	suite.Equal(Fingerprint(`SELECT * FROM t WHERE id IN (1, 2)`), Fingerprint(`select * from t where id in ($1,$2,$3)`))
+}
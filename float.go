@@ -0,0 +1,31 @@
+package queryf
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+func (a *Argument) isFloat() bool {
+	kind := a.getReflectedType().Kind()
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// formatFloat returns the shortest literal that parses back to the same float,
+// quoting NaN and infinities as Postgres expects them, e.g. 'NaN'::float8.
+func (a *Argument) formatFloat() string {
+	v := a.getReflectedValue().Float()
+	typ, bitSize := "float8", 64
+	if a.getReflectedType().Kind() == reflect.Float32 {
+		typ, bitSize = "float4", 32
+	}
+	switch {
+	case math.IsNaN(v):
+		return a.formatter.cast(a.formatter.quote("NaN"), typ)
+	case math.IsInf(v, 1):
+		return a.formatter.cast(a.formatter.quote("Infinity"), typ)
+	case math.IsInf(v, -1):
+		return a.formatter.cast(a.formatter.quote("-Infinity"), typ)
+	}
+	return strconv.FormatFloat(v, 'g', -1, bitSize)
+}
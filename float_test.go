@@ -0,0 +1,20 @@
+package queryf
+
+import "math"
+
+func (suite *QueryfTestSuite) TestFloats() {
+	suite.Equal(`SELECT 1.5, -0.25, 100`, Format(`SELECT $1, $2, $3`, 1.5, float32(-0.25), 100.0))
+	suite.Equal(`SELECT 'NaN'::float8, 'Infinity'::float8, '-Infinity'::float8`, Format(`SELECT $1, $2, $3`, math.NaN(), math.Inf(1), math.Inf(-1)))
+	suite.Equal(`SELECT 'NaN'::float4`, Format(`SELECT $1`, float32(math.NaN())))
+	suite.Equal(`SELECT 'Infinity'`, New(WithDialect(MySQL)).Format(`SELECT ?`, math.Inf(1)))
+	suite.Equal(`SELECT '{1.5,"NaN","-Infinity"}'`, Format(`SELECT $1`, []float64{1.5, math.NaN(), math.Inf(-1)}))
+	suite.Equal(`SELECT '["NaN",1)'`, Format(`SELECT $1`, Range{Lower: math.NaN(), Upper: 1.0}))
+
+	// Floats are formatted with the precision needed to round trip
+	a, b := 0.1, 0.2
+	suite.Equal(`SELECT 0.30000000000000004, 1.7976931348623157e+308, 5e-324`, Format(`SELECT $1, $2, $3`, a+b, math.MaxFloat64, math.SmallestNonzeroFloat64))
+	suite.Equal(`SELECT 0.1, 3.4028235e+38`, Format(`SELECT $1, $2`, float32(0.1), float32(math.MaxFloat32)))
+	suite.Equal(`SELECT 1.2345678901234568e+20`, Format(`SELECT $1`, 123456789012345678901.0))
+
+	suite.Equal(Float, NewArgument(1.5).GetType())
+}
@@ -0,0 +1,344 @@
+package queryf
+
+import (
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// PlaceholderStyle is the syntax used by the query to bind its arguments.
+type PlaceholderStyle string
+
+const (
+	// Dollar is the Postgres style, $1, $2, etc.
+	Dollar PlaceholderStyle = "dollar"
+	// Question is the MySQL and SQLite style, where each ? is bound in order.
+	// A ?? is an escaped ?, as in sqlx, so the Postgres JSON operators ?, ?|
+	// and ?& are written ??, ??| and ??&.
+	Question PlaceholderStyle = "question"
+	// Colon is the Oracle style, :1, :2, etc.
+	Colon PlaceholderStyle = "colon"
+	// AtP is the SQL Server style, @p1, @p2, etc.
+	AtP PlaceholderStyle = "atp"
+	// Named is the :name and @name style used by FormatNamed. Format doesn't
+	// bind it, since its placeholders have no position.
+	Named PlaceholderStyle = "named"
+)
+
+// Formatter holds the configuration used to format queries. The zero value is
+// not usable, use New to create one.
+type Formatter struct {
+	dialect          Dialect
+	timeFormat       string
+	nullLiteral      string
+	quoter           func(s string) string
+	placeholderStyle PlaceholderStyle
+	nilSliceAsEmpty  bool
+	redactors        []func(index int, arg any) bool
+	maxStringLen     int
+	maxBytesLen      int
+	maxSliceElems    int
+	timeLocation     *time.Location
+	timePrecision    time.Duration
+	dateOnly         bool
+	jsonCast         string
+	hstoreMaps       bool
+	escapeStrings    bool
+	unicodeEscapes   bool
+	bytesStyle       BytesStyle
+	booleanStyle     BooleanStyle
+	minorUnits       map[reflect.Type]int
+	moneyCast        bool
+	keywordCase      KeywordCase
+	color            bool
+	keywordColor     bool
+	strictSafety     bool
+	maxDepth         int
+	stringerFallback bool
+	singleLine       bool
+	quoteIdentifiers bool
+	copyText         bool
+	cache            *lruCache[cacheKey, string]
+}
+
+// Option configures a Formatter.
+type Option func(*Formatter)
+
+// WithTimeFormat sets the layout used to format time.Time arguments.
+// Defaults to the dialect time format, time.RFC3339Nano for Postgres.
+func WithTimeFormat(layout string) Option {
+	return func(f *Formatter) {
+		f.timeFormat = layout
+	}
+}
+
+// WithTimeLocation converts time.Time arguments to loc before formatting them,
+// e.g. WithTimeLocation(time.UTC). Defaults to the location of each value.
+func WithTimeLocation(loc *time.Location) Option {
+	return func(f *Formatter) {
+		f.timeLocation = loc
+	}
+}
+
+// WithTimePrecision rounds time.Time arguments to the given precision before
+// formatting them, e.g. WithTimePrecision(time.Microsecond) matches what
+// Postgres stores. Defaults to no rounding.
+func WithTimePrecision(precision time.Duration) Option {
+	return func(f *Formatter) {
+		f.timePrecision = precision
+	}
+}
+
+// WithNullLiteral sets the literal used for nil arguments. Defaults to NULL.
+func WithNullLiteral(literal string) Option {
+	return func(f *Formatter) {
+		f.nullLiteral = literal
+	}
+}
+
+// WithQuoter sets the function used to quote string literals. Defaults to the
+// dialect quoting, which wraps the value in single quotes.
+func WithQuoter(quoter func(s string) string) Option {
+	return func(f *Formatter) {
+		f.quoter = quoter
+	}
+}
+
+// WithNilSliceAsEmpty formats nil slices as empty arrays instead of NULL.
+func WithNilSliceAsEmpty() Option {
+	return func(f *Formatter) {
+		f.nilSliceAsEmpty = true
+	}
+}
+
+// WithPlaceholderStyle sets the placeholder syntax used by the queries.
+// Defaults to the dialect placeholder style, Dollar for Postgres.
+func WithPlaceholderStyle(style PlaceholderStyle) Option {
+	return func(f *Formatter) {
+		f.placeholderStyle = style
+	}
+}
+
+// New returns a Formatter configured with the given options.
+//
+// Example:
+//
+//	f := queryf.New(queryf.WithTimeFormat("2006-01-02"), queryf.WithNullLiteral("null"))
+//	fmt.Println(f.Format("SELECT $1, $2", t, nil))
+//	// Output: SELECT '2022-02-10', null
+func New(opts ...Option) *Formatter {
+	f := &Formatter{
+		dialect:     Postgres,
+		nullLiteral: "NULL",
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+var (
+	// builtinFormatter is the package level configuration until SetDefault is
+	// called.
+	builtinFormatter = New()
+	defaultFormatter atomic.Pointer[Formatter]
+)
+
+// SetDefault sets the Formatter used by the package level functions, such as
+// Format, and by the integrations that default to them. It is meant to be
+// called once at startup, but it is safe to call concurrently with them. A nil
+// Formatter restores the defaults.
+//
+// Example:
+//
+//	queryf.SetDefault(queryf.New(queryf.WithDialect(queryf.MySQL), queryf.WithRedactedParams(2)))
+func SetDefault(f *Formatter) {
+	defaultFormatter.Store(f)
+}
+
+// Default returns the Formatter used by the package level functions.
+func Default() *Formatter {
+	if f := defaultFormatter.Load(); f != nil {
+		return f
+	}
+	return builtinFormatter
+}
+
+// With returns a copy of the Formatter with the options applied on top of its
+// configuration. f is left untouched, so formatters can be derived from a
+// shared one concurrently.
+//
+// Example:
+//
+//	short := f.With(queryf.WithMaxStringLen(64))
+func (f *Formatter) With(opts ...Option) *Formatter {
+	c := *f
+	// Options such as WithRedactor append to the slice, which must not grow
+	// into the backing array of f.
+	c.redactors = c.redactors[:len(c.redactors):len(c.redactors)]
+	// The cached queries were formatted with the configuration of f.
+	c.cache = nil
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return &c
+}
+
+// callOptions separates the options passed among the arguments of a call from
+// the arguments themselves. It returns nil options when there are none.
+func callOptions(args []any) ([]Option, []any) {
+	var opts []Option
+	var rest []any
+	for i, arg := range args {
+		opt, ok := arg.(Option)
+		if !ok {
+			if opts != nil {
+				rest = append(rest, arg)
+			}
+			continue
+		}
+		if opts == nil {
+			rest = append([]any{}, args[:i]...)
+		}
+		opts = append(opts, opt)
+	}
+	return opts, rest
+}
+
+// Format will return the query with the arguments formatted using the
+// Formatter configuration. Options passed among the arguments apply to this
+// call only, as if the Formatter had been derived with With. See the package
+// level Format for details.
+//
+// Example:
+//
+//	fmt.Println(f.Format("SELECT $1", strings.Repeat("a", 100), queryf.WithMaxStringLen(5)))
+//	// Output: SELECT 'aaaaa…'(+95 bytes)
+func (f *Formatter) Format(query string, args ...any) string {
+	if opts, rest := callOptions(args); opts != nil {
+		return f.With(opts...).Format(query, rest...)
+	}
+	query, args = rewriteQuery(query, args)
+	// Locked Formatters depend on the environment, not only on their options.
+	if f.cache != nil && !f.strictSafety {
+		key, ok := cacheKeyOf(query, args)
+		if !ok {
+			return f.format(query, args)
+		}
+		if formatted, ok := f.cache.get(key); ok {
+			return formatted
+		}
+		formatted := f.format(query, args)
+		f.cache.put(key, formatted)
+		return formatted
+	}
+	return f.format(query, args)
+}
+
+// format returns the query with the arguments formatted, like Format without
+// the per-call options and the cache.
+func (f *Formatter) format(query string, args []any) string {
+	if f.singleLine {
+		query = compact(query, f.syntax())
+	}
+	if f.keywordColor {
+		query = colorKeywords(query, f.syntax())
+	}
+	// Each argument is formatted once, however many placeholders refer to it.
+	// Most queries have few arguments, so their literals fit in the arrays.
+	var literals [16]string
+	var known [16]bool
+	formatted, done := literals[:], known[:]
+	if len(args) > len(literals) {
+		formatted, done = make([]string, len(args)), make([]bool, len(args))
+	}
+	return substitute(query, f.syntax(), func(p placeholder) (string, bool) {
+		i := p.index - 1
+		if i < 0 || i >= len(args) {
+			return "", false
+		}
+		if !done[i] {
+			formatted[i] = f.formatArg(p.index, args[i])
+			done[i] = true
+		}
+		return formatted[i], true
+	})
+}
+
+// FormatArgs returns the literal of each argument, formatted with the Formatter
+// configuration. See the package level FormatArgs for details.
+func (f *Formatter) FormatArgs(args ...any) []string {
+	literals := make([]string, len(args))
+	for i, arg := range args {
+		literals[i] = f.formatArg(i+1, arg)
+	}
+	return literals
+}
+
+// NewArgument returns an Argument that will be formatted with the Formatter
+// configuration, redacted like a named argument.
+func (f *Formatter) NewArgument(arg any) *Argument {
+	return &Argument{arg: arg, formatter: f}
+}
+
+// formatArg returns the literal of the argument bound to the 1-based index, or
+// to a named placeholder when index is 0.
+func (f *Formatter) formatArg(index int, arg any) string {
+	if f.color {
+		return colorize(f.literal(index, arg), colorValue)
+	}
+	return f.literal(index, arg)
+}
+
+// literal returns the literal of the argument like formatArg, without colors.
+func (f *Formatter) literal(index int, arg any) string {
+	return (&Argument{arg: arg, formatter: f, index: index}).Format()
+}
+
+// style returns the placeholder style of the queries.
+func (f *Formatter) style() PlaceholderStyle {
+	if f.placeholderStyle != "" {
+		return f.placeholderStyle
+	}
+	return f.dialect.PlaceholderStyle()
+}
+
+// syntax returns the syntax the queries of the Formatter are tokenized with.
+func (f *Formatter) syntax() syntax {
+	d, ok := f.dialect.(BackslashEscapes)
+	return syntax{style: f.style(), backslash: ok && d.BackslashEscapes()}
+}
+
+// quote returns s as a string literal.
+func (f *Formatter) quote(s string) string {
+	if f.quoter != nil {
+		return f.quoter(s)
+	}
+	if f.unicodeEscapes && f.dialect == Postgres && needsUnicodeEscape(s) {
+		return unicodeEscapeString(s)
+	}
+	if (f.escapeStrings && needsEscape(s) || f.singleLine && hasNewline(s)) && f.dialect == Postgres {
+		return escapeString(s)
+	}
+	return f.dialect.QuoteString(s)
+}
+
+// adjustTime returns t in the configured location and precision.
+func (f *Formatter) adjustTime(t time.Time) time.Time {
+	if f.timeLocation != nil {
+		t = t.In(f.timeLocation)
+	}
+	if f.timePrecision > 0 {
+		t = t.Round(f.timePrecision)
+	}
+	return t
+}
+
+// formatTime returns the timestamp literal for t.
+func (f *Formatter) formatTime(t time.Time) string {
+	t = f.adjustTime(t)
+	if f.timeFormat != "" {
+		return f.quote(t.Format(f.timeFormat))
+	}
+	return f.dialect.FormatTime(t)
+}
@@ -0,0 +1,79 @@
+package queryf
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+func (suite *QueryfTestSuite) TestFormatterOptions() {
+	t, err := time.Parse(time.RFC3339, "2022-02-10T13:45:00Z")
+	suite.Nil(err)
+
+	f := New(WithTimeFormat("2006-01-02"), WithNullLiteral("null"))
+	suite.Equal(`SELECT '2022-02-10', null`, f.Format(`SELECT $1, $2`, t, nil))
+
+	v := int64(5)
+	suite.Equal(`SELECT '{5,null}'`, f.Format(`SELECT $1`, []*int64{&v, nil}))
+
+	f = New(WithQuoter(func(s string) string { return fmt.Sprintf("\"%s\"", s) }))
+	suite.Equal(`SELECT "John", "{1,2}"`, f.Format(`SELECT $1, $2`, "John", []int{1, 2}))
+}
+
+func (suite *QueryfTestSuite) TestDefaultFormatter() {
+	suite.Equal(Format(`SELECT $1, $2`, "John", nil), New().Format(`SELECT $1, $2`, "John", nil))
+}
+
+func (suite *QueryfTestSuite) TestQuestionPlaceholders() {
+	f := New(WithPlaceholderStyle(Question))
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND name = 'John'`,
+		f.Format(`SELECT * FROM users WHERE id = ? AND name = ?`, 1, "John"))
+
+	// Placeholders without arguments are kept
+	suite.Equal(`SELECT 1, ?`, f.Format(`SELECT ?, ?`, 1))
+
+	// $N is not a placeholder in this style
+	suite.Equal(`SELECT $1, 2`, f.Format(`SELECT $1, ?`, 2))
+}
+
+func (suite *QueryfTestSuite) TestFormatArgs() {
+	suite.Equal([]string{`1`, `'John'`, `NULL`, `'{1,2}'`}, FormatArgs(1, "John", nil, []int{1, 2}))
+	suite.Equal([]string{`1`, `'[REDACTED]'`}, New(WithRedactedParams(2)).FormatArgs(1, "secret"))
+	suite.Equal([]string{}, FormatArgs())
+}
+
+func (suite *QueryfTestSuite) TestWith() {
+	f := New(WithRedactedParams(1))
+	derived := f.With(WithNullLiteral("null"), WithRedactedParams(2))
+	suite.Equal(`SELECT '[REDACTED]', '[REDACTED]', null`, derived.Format(`SELECT $1, $2, $3`, 1, 2, nil))
+	suite.Equal(`SELECT '[REDACTED]', 2, NULL`, f.Format(`SELECT $1, $2, $3`, 1, 2, nil))
+
+	// Deriving twice from the same Formatter doesn't share the redactors.
+	first, second := f.With(WithRedactedParams(2)), f.With(WithRedactedParams(3))
+	suite.Equal(`SELECT '[REDACTED]', '[REDACTED]', 3`, first.Format(`SELECT $1, $2, $3`, 1, 2, 3))
+	suite.Equal(`SELECT '[REDACTED]', 2, '[REDACTED]'`, second.Format(`SELECT $1, $2, $3`, 1, 2, 3))
+}
+
+func (suite *QueryfTestSuite) TestCallOptions() {
+	f := New()
+	long := strings.Repeat("a", 10)
+	suite.Equal(`SELECT 'aaa…'(+7 bytes), 1`, f.Format(`SELECT $1, $2`, long, WithMaxStringLen(3), 1))
+	suite.Equal(`SELECT 'aaaaaaaaaa', 1`, f.Format(`SELECT $1, $2`, long, 1))
+	suite.Equal(`SELECT null`, Format(`SELECT $1`, nil, WithNullLiteral("null")))
+
+	query, err := f.FormatE(`SELECT $1`, nil, WithNullLiteral("null"))
+	suite.NoError(err)
+	suite.Equal(`SELECT null`, query)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			suite.Equal(fmt.Sprintf(`SELECT '[REDACTED]', %d`, i), f.Format(`SELECT $1, $2`, "secret", i, WithRedactedParams(1)))
+		}(i)
+	}
+	wg.Wait()
+	suite.Equal(`SELECT 'secret'`, f.Format(`SELECT $1`, "secret"))
+}
@@ -0,0 +1,207 @@
+package queryf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoQuery is returned by FormatFromLog when the log line has no query.
+var ErrNoQuery = errors.New("queryf: no query found in log line")
+
+var (
+	// gormLine matches the GORM debug output, whose SQL is already formatted.
+	gormLine = regexp.MustCompile(`\[rows:-?\d+\]\s+(.+)$`)
+	// logKey matches the start of the query and args fields of a logfmt line.
+	logKey = regexp.MustCompile(`(?:^|\s)(query|sql|args)=`)
+)
+
+// FormatFromLog extracts the query and its arguments from a log line and
+// returns the query formatted. It recognizes logfmt lines with query= or sql=
+// and args= fields, as written by pgx and slog text handlers, JSON lines with
+// the same fields, and GORM debug output. The arguments can be a JSON array or
+// a Go formatted slice, where unquoted words are strings.
+//
+// Example:
+//
+//	query, _ := queryf.FormatFromLog(`level=INFO msg=Query sql="SELECT * FROM users WHERE id = $1" args="[1]"`)
+//	fmt.Println(query)
+//	// Output: SELECT * FROM users WHERE id = 1
+func FormatFromLog(line string) (string, error) {
+	return Default().FormatFromLog(line)
+}
+
+// FormatFromLog extracts the query and its arguments from a log line and
+// formats them with the Formatter configuration. See the package level
+// FormatFromLog for details.
+func (f *Formatter) FormatFromLog(line string) (string, error) {
+	line = strings.TrimSpace(line)
+	if m := gormLine.FindStringSubmatch(line); m != nil {
+		return m[1], nil
+	}
+	query, args, ok := parseJSONLog(line)
+	if !ok {
+		query, args = parseLogfmt(line)
+	}
+	if query == "" {
+		return "", ErrNoQuery
+	}
+	values, err := parseLogArgs(args)
+	if err != nil {
+		return "", err
+	}
+	return f.Format(query, values...), nil
+}
+
+// parseJSONLog returns the query and the raw args of a JSON log line, or false
+// if the line isn't a JSON object.
+func parseJSONLog(line string) (query, args string, ok bool) {
+	var fields map[string]json.RawMessage
+	if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &fields) != nil {
+		return "", "", false
+	}
+	for _, key := range []string{"query", "sql"} {
+		if json.Unmarshal(fields[key], &query) == nil && query != "" {
+			break
+		}
+	}
+	if err := json.Unmarshal(fields["args"], &args); err != nil {
+		args = string(fields["args"])
+	}
+	return query, args, true
+}
+
+// parseLogfmt returns the query and the raw args of a logfmt line. Unquoted
+// queries extend up to the args field or to the end of the line.
+func parseLogfmt(line string) (query, args string) {
+	matches := logKey.FindAllStringSubmatchIndex(line, -1)
+	for i, m := range matches {
+		key, start := line[m[2]:m[3]], m[1]
+		next := len(line)
+		if i+1 < len(matches) {
+			next = matches[i+1][0]
+		}
+		value := logValue(line[start:], next-start, key != "args")
+		if key == "args" {
+			args = value
+		} else if query == "" {
+			query = value
+		}
+	}
+	return query, args
+}
+
+// logValue returns the value at the start of s: a Go quoted string, a bracketed
+// list, or the text up to the first space, or up to end when spaces is true.
+func logValue(s string, end int, spaces bool) string {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		if quoted, err := strconv.QuotedPrefix(s); err == nil {
+			v, _ := strconv.Unquote(quoted)
+			return v
+		}
+	case strings.HasPrefix(s, "["):
+		if n := listEnd(s); n > 0 {
+			return s[:n]
+		}
+	}
+	if !spaces {
+		if n := strings.IndexByte(s, ' '); n != -1 && n < end {
+			end = n
+		}
+	}
+	return strings.TrimSpace(s[:end])
+}
+
+// listEnd returns the end of the bracketed list at the start of s, skipping
+// brackets inside quoted strings, or 0 if it isn't closed.
+func listEnd(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted, err := strconv.QuotedPrefix(s[i:])
+			if err != nil {
+				return 0
+			}
+			i += len(quoted) - 1
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
+
+// parseLogArgs returns the arguments of a JSON array or a Go formatted slice,
+// such as [1 "John" <nil>].
+func parseLogArgs(s string) ([]any, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "[]" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("queryf: invalid log args: %s", s)
+	}
+	var args []any
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	if dec.Decode(&args) == nil {
+		for i, arg := range args {
+			args[i] = logArg(arg)
+		}
+		return args, nil
+	}
+	args = nil
+	for rest := strings.TrimSpace(s[1 : len(s)-1]); rest != ""; rest = strings.TrimSpace(rest) {
+		word := rest
+		if quoted, err := strconv.QuotedPrefix(rest); err == nil {
+			word = quoted
+		} else if n := strings.IndexByte(rest, ' '); n != -1 {
+			word = rest[:n]
+		}
+		rest = rest[len(word):]
+		args = append(args, logWord(word))
+	}
+	return args, nil
+}
+
+// logArg converts the JSON numbers of a decoded argument to int64 or float64.
+func logArg(arg any) any {
+	n, ok := arg.(json.Number)
+	if !ok {
+		return arg
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	v, _ := n.Float64()
+	return v
+}
+
+// logWord returns the value of a word of a Go formatted slice.
+func logWord(word string) any {
+	if s, err := strconv.Unquote(word); err == nil {
+		return s
+	}
+	switch word {
+	case "<nil>", "nil", "NULL":
+		return nil
+	case "true", "false":
+		return word == "true"
+	}
+	if i, err := strconv.ParseInt(word, 10, 64); err == nil {
+		return i
+	}
+	if v, err := strconv.ParseFloat(word, 64); err == nil {
+		return v
+	}
+	return word
+}
@@ -0,0 +1,54 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestFormatFromLog() {
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{
+			line:     `level=INFO msg=Query sql="SELECT * FROM users WHERE id = $1 AND name = $2" args="[1 John]"`,
+			expected: `SELECT * FROM users WHERE id = 1 AND name = 'John'`,
+		},
+		{
+			line:     `query=SELECT * FROM users WHERE id = $1 AND name = $2 args=[1 "John Doe"]`,
+			expected: `SELECT * FROM users WHERE id = 1 AND name = 'John Doe'`,
+		},
+		{
+			line:     `2024/01/02 10:00:00 query="SELECT $1, $2, $3, $4" args=[<nil> true 1.5 "a]b"] pid=12`,
+			expected: `SELECT NULL, true, 1.5, 'a]b'`,
+		},
+		{
+			line:     `{"level":"debug","sql":"SELECT * FROM users WHERE id = $1 AND name = $2","args":[9007199254740993,"John"]}`,
+			expected: `SELECT * FROM users WHERE id = 9007199254740993 AND name = 'John'`,
+		},
+		{
+			line:     `{"msg":"Query","query":"SELECT $1","args":"[2]"}`,
+			expected: `SELECT 2`,
+		},
+		{
+			line:     `sql="SELECT now()"`,
+			expected: `SELECT now()`,
+		},
+		{
+			line:     `2024/01/02 10:00:00 /app/users.go:12 [1.234ms] [rows:1] SELECT * FROM "users" WHERE id = 1`,
+			expected: `SELECT * FROM "users" WHERE id = 1`,
+		},
+	}
+	for _, test := range tests {
+		query, err := FormatFromLog(test.line)
+		suite.NoError(err, test.line)
+		suite.Equal(test.expected, query, test.line)
+	}
+
+	f := New(WithPlaceholderStyle(Question))
+	query, err := f.FormatFromLog(`query="SELECT * FROM users WHERE id = ?" args=[1]`)
+	suite.NoError(err)
+	suite.Equal(`SELECT * FROM users WHERE id = 1`, query)
+
+	_, err = FormatFromLog(`level=INFO msg="connection established"`)
+	suite.ErrorIs(err, ErrNoQuery)
+	_, err = FormatFromLog(`{"msg":"connection established"}`)
+	suite.ErrorIs(err, ErrNoQuery)
+	_, err = FormatFromLog(`query="SELECT $1" args=1`)
+	suite.EqualError(err, `queryf: invalid log args: 1`)
+}
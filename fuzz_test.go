@@ -0,0 +1,75 @@
+package queryf
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func FuzzFormat(f *testing.F) {
+	f.Add(`SELECT * FROM users WHERE id = $1 AND name = $2`, "John", int64(1))
+	f.Add(`SELECT '$1', "$2", $$ $1 $$, $tag$ $2 $tag$ -- $1`, "it's", int64(-1))
+	f.Add(`SELECT E'\'$1', /* /* $1 */ */ $2`, "\\", int64(0))
+	f.Add(`SELECT $1, $`, "", int64(99))
+	f.Fuzz(func(t *testing.T, query, s string, n int64) {
+		for _, style := range []PlaceholderStyle{Dollar, Question, Colon, AtP, Named} {
+			formatter := New(WithPlaceholderStyle(style))
+			if _, err := formatter.FuzzSafeFormat(query, s, n, []string{s}, map[string]string{"k": s}); err != nil {
+				t.Fatalf("%s: %v", style, err)
+			}
+		}
+	})
+}
+
+func FuzzTokenizer(f *testing.F) {
+	f.Add(`SELECT '$1', "$2", $$ $1 $$, $tag$ $2 $tag$ -- $1`)
+	f.Add(`SELECT E'\'', /* /* */ */ ?, :1, @p1, :name`)
+	f.Add(`SELECT 'unterminated`)
+	f.Fuzz(func(t *testing.T, query string) {
+		for _, style := range []PlaceholderStyle{Dollar, Question, Colon, AtP, Named} {
+			tokenizer := newTokenizer(query, syntax{style: style})
+			end := 0
+			for tok, ok := tokenizer.next(); ok; tok, ok = tokenizer.next() {
+				if tok.start != end || tok.end <= tok.start || tok.end > len(query) {
+					t.Fatalf("%s: token [%d, %d) after offset %d of %q", style, tok.start, tok.end, end, query)
+				}
+				end = tok.end
+			}
+			if end != len(query) {
+				t.Fatalf("%s: tokens end at %d of %d", style, end, len(query))
+			}
+		}
+	})
+}
+
+func FuzzQuoteString(f *testing.F) {
+	f.Add("John")
+	f.Add("it's")
+	f.Add(`back\slash`)
+	f.Add("new\nline\x00")
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			t.Skip("Postgres strings are valid UTF-8")
+		}
+		for _, formatter := range []*Formatter{New(), New(WithEscapeStrings()), New(WithUnicodeEscapes())} {
+			literal := formatter.quote(s)
+			v, ok := unquote(literal)
+			if !ok || v != s {
+				t.Fatalf("%s doesn't unquote to %q", literal, s)
+			}
+			// The literal ends with a single string token, so it can't be closed
+			// early, e.g. by an escaped quote.
+			tokenizer := newTokenizer(literal, syntax{style: Dollar})
+			var last token
+			for tok, ok := tokenizer.next(); ok; tok, ok = tokenizer.next() {
+				last = tok
+			}
+			if last.kind != tokenString || last.unterminated || !strings.HasPrefix(literal[last.start:], "'") && !strings.HasPrefix(literal[last.start:], "E'") {
+				t.Fatalf("%s doesn't end with a string literal", literal)
+			}
+			if prefix := literal[:last.start]; prefix != "" && prefix != "U&" {
+				t.Fatalf("%s has %q before its string literal", literal, prefix)
+			}
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package queryf
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Hash returns an identifier of the query with its arguments: 16 hexadecimal
+// digits that are the same in every process and service formatting the same
+// query with the same arguments, whatever whitespace the query has. Logs and
+// traces can carry the hash of a query instead of its text to be correlated.
+//
+// Example:
+//
+//	logger.Info("query done", "query_hash", queryf.Hash(query, args...))
+func Hash(query string, args ...any) string {
+	return Default().Hash(query, args...)
+}
+
+// Hash returns an identifier of the query formatted with its arguments by the
+// Formatter. See the package level Hash for details.
+func (f *Formatter) Hash(query string, args ...any) string {
+	plain := *f
+	plain.cache = nil
+	plain.color, plain.keywordColor = false, false
+	return hashString(plain.Compact(plain.Format(query, args...)))
+}
+
+// QueryID returns an identifier of the query regardless of its values: the
+// hash of its Fingerprint, so queries only differing in their literals,
+// placeholders or IN lists have the same ID. Like Hash, it is stable across
+// processes, so metrics and logs of different services can be grouped by it.
+//
+// Example:
+//
+//	fmt.Println(queryf.QueryID("SELECT * FROM users WHERE id = 1") == queryf.QueryID("select * from users where id = $1"))
+//	// Output: true
+func QueryID(query string) string {
+	return Default().QueryID(query)
+}
+
+// QueryID returns the identifier of the query fingerprint, using the
+// Formatter placeholder style. See the package level QueryID for details.
+func (f *Formatter) QueryID(query string) string {
+	return hashString(f.Fingerprint(query))
+}
+
+// hashString returns the 64-bit FNV-1a hash of s in hexadecimal.
+func hashString(s string) string {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
@@ -0,0 +1,19 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestHash() {
+	hash := Hash("SELECT * FROM users WHERE id = $1", 1)
+	suite.Len(hash, 16)
+	suite.Equal(hash, Hash("SELECT *\n  FROM users\n WHERE id = 1"))
+	suite.Equal(hash, New(WithColor()).Hash("SELECT * FROM users WHERE id = $1", 1))
+	suite.NotEqual(hash, Hash("SELECT * FROM users WHERE id = $1", 2))
+	suite.Equal(hashString("SELECT * FROM users WHERE id = 1"), hash)
+	suite.Equal("cbf29ce484222325", hashString(""))
+}
+
+func (suite *QueryfTestSuite) TestQueryID() {
+	id := QueryID("SELECT * FROM users WHERE id = 1")
+	suite.Equal(id, QueryID("select *\nfrom users where id = $1"))
+	suite.NotEqual(id, QueryID("SELECT * FROM users WHERE name = 'John'"))
+	suite.Equal(hashString("select * from users where id = ?"), id)
+	suite.Equal(id, New(WithPlaceholderStyle(Question)).QueryID("SELECT * FROM users WHERE id = ?"))
+}
@@ -0,0 +1,80 @@
+package queryf
+
+import (
+	"database/sql"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq/hstore"
+)
+
+var hstoreEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// WithHstoreMaps formats map[string]string, map[string]*string and
+// map[string]sql.NullString arguments as hstore literals instead of JSON, e.g.
+// '"k"=>"v", "k2"=>NULL'::hstore. hstore.Hstore and pgtype.Hstore values are
+// always formatted as hstore.
+func WithHstoreMaps() Option {
+	return func(f *Formatter) {
+		f.hstoreMaps = true
+	}
+}
+
+func (a *Argument) isHstore() bool {
+	if _, ok := a.arg.(hstore.Hstore); ok {
+		return true
+	}
+	if isNamedType(a.getReflectedType(), pgtypePkgPath, "Hstore") {
+		return true
+	}
+	if !a.formatter.hstoreMaps {
+		return false
+	}
+	switch a.arg.(type) {
+	case map[string]string, map[string]*string, map[string]sql.NullString:
+		return true
+	}
+	return false
+}
+
+// formatHstore returns the hstore literal of the map with its keys sorted.
+func (a *Argument) formatHstore() string {
+	rv := a.getReflectedValue()
+	if h, ok := a.arg.(hstore.Hstore); ok {
+		if h.Map == nil {
+			return a.formatNull()
+		}
+		rv = reflect.ValueOf(h.Map)
+	}
+	if rv.IsNil() {
+		return a.formatNull()
+	}
+	keys := make([]string, 0, rv.Len())
+	values := make(map[string]*string, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key := iter.Key().String()
+		keys = append(keys, key)
+		switch v := iter.Value().Interface().(type) {
+		case string:
+			values[key] = &v
+		case *string:
+			values[key] = v
+		case sql.NullString:
+			if v.Valid {
+				values[key] = &v.String
+			}
+		}
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		value := "NULL"
+		if values[key] != nil {
+			value = `"` + hstoreEscaper.Replace(*values[key]) + `"`
+		}
+		pairs[i] = `"` + hstoreEscaper.Replace(key) + `"=>` + value
+	}
+	return a.formatter.cast(a.formatter.quote(strings.Join(pairs, ", ")), "hstore")
+}
@@ -0,0 +1,37 @@
+package queryf
+
+import (
+	"database/sql"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lib/pq/hstore"
+)
+
+func (suite *QueryfTestSuite) TestHstore() {
+	f := New(WithHstoreMaps())
+	v := "x"
+	suite.Equal(`SELECT '"a"=>"1", "b"=>"it''s"'::hstore`, f.Format(`SELECT $1`, map[string]string{"b": "it's", "a": "1"}))
+	suite.Equal(`SELECT '"a"=>"x", "b"=>NULL'::hstore`, f.Format(`SELECT $1`, map[string]*string{"b": nil, "a": &v}))
+	suite.Equal(`SELECT '"a"=>"x", "b"=>NULL'::hstore`, f.Format(`SELECT $1`, map[string]sql.NullString{"a": {String: "x", Valid: true}, "b": {}}))
+	suite.Equal(`SELECT '"\"k\""=>"\\"'::hstore`, f.Format(`SELECT $1`, map[string]string{`"k"`: `\`}))
+	suite.Equal(`SELECT ''::hstore`, f.Format(`SELECT $1`, map[string]string{}))
+	suite.Equal(`SELECT '{"a":1}'`, f.Format(`SELECT $1`, map[string]int{"a": 1}))
+
+	var m map[string]string
+	suite.Equal(`SELECT NULL`, f.Format(`SELECT $1`, m))
+	suite.Equal(`SELECT '{"a":"1"}'`, Format(`SELECT $1`, map[string]string{"a": "1"}))
+	suite.Equal(Hstore, f.NewArgument(map[string]string{}).GetType())
+	suite.Equal(Map, NewArgument(map[string]string{}).GetType())
+}
+
+func (suite *QueryfTestSuite) TestHstoreTypes() {
+	h := hstore.Hstore{Map: map[string]sql.NullString{"b": {}, "a": {String: "1", Valid: true}}}
+	suite.Equal(`SELECT '"a"=>"1", "b"=>NULL'::hstore`, Format(`SELECT $1`, h))
+	suite.Equal(`SELECT '"a"=>"1", "b"=>NULL'::hstore`, Format(`SELECT $1`, &h))
+	suite.Equal(`SELECT NULL`, Format(`SELECT $1`, hstore.Hstore{}))
+
+	v := "1"
+	suite.Equal(`SELECT '"a"=>"1", "b"=>NULL'::hstore`, Format(`SELECT $1`, pgtype.Hstore{"b": nil, "a": &v}))
+	suite.Equal(`SELECT NULL`, Format(`SELECT $1`, pgtype.Hstore(nil)))
+	suite.Equal(Hstore, NewArgument(pgtype.Hstore{}).GetType())
+}
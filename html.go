@@ -0,0 +1,46 @@
+package queryf
+
+import (
+	"html"
+	"html/template"
+	"strings"
+)
+
+// FormatHTML formats the query like Format, escaped for HTML, with each
+// argument wrapped in a <span class="queryf-arg"> tag so debug pages can
+// highlight them.
+//
+// Example:
+//
+//	fmt.Println(queryf.FormatHTML("SELECT * FROM users WHERE name = $1", "<John>"))
+//	// Output: SELECT * FROM users WHERE name = <span class="queryf-arg">&#39;&lt;John&gt;&#39;</span>
+func FormatHTML(query string, args ...any) template.HTML {
+	return Default().FormatHTML(query, args...)
+}
+
+// FormatHTML formats the query for HTML with the Formatter configuration. See
+// the package level FormatHTML for details.
+func (f *Formatter) FormatHTML(query string, args ...any) template.HTML {
+	query, args = rewriteQuery(query, args)
+	var b strings.Builder
+	last := 0
+	for _, p := range scanPlaceholders(query, f.syntax()) {
+		if p.escaped {
+			b.WriteString(html.EscapeString(query[last:p.start]))
+			b.WriteByte('?')
+			last = p.end
+			continue
+		}
+		i := p.index - 1
+		if i < 0 || i >= len(args) {
+			continue
+		}
+		b.WriteString(html.EscapeString(query[last:p.start]))
+		b.WriteString(`<span class="queryf-arg">`)
+		b.WriteString(html.EscapeString(f.literal(p.index, args[i])))
+		b.WriteString(`</span>`)
+		last = p.end
+	}
+	b.WriteString(html.EscapeString(query[last:]))
+	return template.HTML(b.String())
+}
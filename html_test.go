@@ -0,0 +1,20 @@
+package queryf
+
+import (
+	"html/template"
+	"strings"
+)
+
+func (suite *QueryfTestSuite) TestFormatHTML() {
+	suite.Equal(template.HTML(`SELECT * FROM t WHERE a &lt; <span class="queryf-arg">1</span> AND b = <span class="queryf-arg">&#39;&lt;b&gt;it&#39;&#39;s&lt;/b&gt;&#39;</span> AND c = $3`),
+		FormatHTML(`SELECT * FROM t WHERE a < $1 AND b = $2 AND c = $3`, 1, "<b>it's</b>"))
+	suite.Equal(template.HTML(`SELECT <span class="queryf-arg">1</span>`), New(WithColor()).FormatHTML(`SELECT $1`, 1))
+
+	suite.Equal(template.HTML(`SELECT data ? &#39;a&#39;, <span class="queryf-arg">1</span>`),
+		New(WithPlaceholderStyle(Question)).FormatHTML(`SELECT data ?? 'a', ?`, 1))
+
+	tmpl := template.Must(template.New("").Parse(`<pre>{{.}}</pre>`))
+	var b strings.Builder
+	suite.Nil(tmpl.Execute(&b, FormatHTML(`SELECT $1`, "&")))
+	suite.Equal(`<pre>SELECT <span class="queryf-arg">&#39;&amp;&#39;</span></pre>`, b.String())
+}
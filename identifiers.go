@@ -0,0 +1,68 @@
+package queryf
+
+import "strings"
+
+// WithQuoteIdentifiers quotes the table, column and alias names written by
+// Pretty, e.g. SELECT "id" FROM "users", with backticks for MySQL and brackets
+// for SQL Server. Unquoted names are folded as the database would, to lower
+// case for Postgres and upper case for Oracle, so they keep referring to the
+// same objects. Function names and types are left as written.
+func WithQuoteIdentifiers() Option {
+	return func(f *Formatter) {
+		f.quoteIdentifiers = true
+	}
+}
+
+// sqlWords are the words besides keywords that are never quoted as
+// identifiers, because they are part of the SQL syntax.
+var sqlWords = map[string]bool{
+	"array": true, "asymmetric": true, "at": true, "cast": true, "collate": true, "current": true,
+	"current_date": true, "current_time": true, "current_timestamp": true, "current_user": true,
+	"date": true, "day": true, "default": true, "escape": true, "fetch": true, "filter": true, "first": true,
+	"following": true, "hour": true, "interval": true, "isnull": true, "last": true, "lateral": true,
+	"localtime": true, "localtimestamp": true, "lock": true, "locked": true, "minute": true,
+	"month": true, "natural": true,
+	"next": true, "no": true, "notnull": true, "nowait": true, "nulls": true, "of": true,
+	"only": true, "over": true, "overlaps": true, "partition": true, "preceding": true,
+	"range": true, "recursive": true, "row": true, "rows": true, "second": true, "session_user": true,
+	"share": true, "similar": true, "skip": true, "some": true, "symmetric": true, "ties": true,
+	"time": true, "timestamp": true, "top": true, "unbounded": true, "unknown": true,
+	"window": true, "year": true, "zone": true,
+}
+
+// quoteIdentifierLexemes returns the lexemes with the identifiers quoted. Words
+// followed by ( are function names, words after :: are types, words after $,
+// : and @ are placeholders, and words followed by a string literal are typed
+// literals like DATE '2022-02-10'.
+func (f *Formatter) quoteIdentifierLexemes(ls []lexeme) []lexeme {
+	for i, l := range ls {
+		word := strings.ToLower(l.text)
+		if l.kind != lexemeWord || keywords[word] || sqlWords[word] || isDigit(l.text[0]) {
+			continue
+		}
+		if i > 0 && strings.Contains(":: $ : @", ls[i-1].text) && !ls[i].space {
+			continue
+		}
+		if i+1 < len(ls) && (ls[i+1].text == "(" || ls[i+1].kind == lexemeLiteral && strings.HasPrefix(ls[i+1].text, "'")) {
+			continue
+		}
+		ls[i].text = f.quoteIdentifier(l.text)
+		ls[i].kind = lexemeLiteral
+	}
+	return ls
+}
+
+// quoteIdentifier returns the unquoted name quoted in the dialect syntax.
+func (f *Formatter) quoteIdentifier(name string) string {
+	switch f.dialect {
+	case Postgres:
+		return `"` + strings.ToLower(name) + `"`
+	case Oracle:
+		return `"` + strings.ToUpper(name) + `"`
+	case MySQL, BigQuery:
+		return "`" + name + "`"
+	case SQLServer:
+		return "[" + name + "]"
+	}
+	return `"` + name + `"`
+}
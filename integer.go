@@ -0,0 +1,26 @@
+package queryf
+
+import (
+	"reflect"
+	"strconv"
+)
+
+func (a *Argument) isInteger() bool {
+	switch a.getReflectedType().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// formatInteger returns the decimal literal of the integer. Named types are
+// formatted by their kind, as database/sql converts them, so time.Duration is
+// formatted in nanoseconds.
+func (a *Argument) formatInteger() string {
+	rv := a.getReflectedValue()
+	if rv.CanInt() {
+		return strconv.FormatInt(rv.Int(), 10)
+	}
+	return strconv.FormatUint(rv.Uint(), 10)
+}
@@ -0,0 +1,30 @@
+package queryf
+
+import (
+	"math"
+	"time"
+)
+
+type level int8
+
+func (suite *QueryfTestSuite) TestIntegers() {
+	suite.Equal(`SELECT 1, -8, -16, -32, -64`, Format(`SELECT $1, $2, $3, $4, $5`, 1, int8(-8), int16(-16), int32(-32), int64(-64)))
+	suite.Equal(`SELECT 1, 8, 16, 32, 64, 128`, Format(`SELECT $1, $2, $3, $4, $5, $6`, uint(1), uint8(8), uint16(16), uint32(32), uint64(64), uintptr(128)))
+	suite.Equal(`SELECT 18446744073709551615, 9223372036854775808`, Format(`SELECT $1, $2`, uint64(math.MaxUint64), uint64(math.MaxInt64)+1))
+	suite.Equal(`SELECT -9223372036854775808`, Format(`SELECT $1`, int64(math.MinInt64)))
+	suite.Equal(`SELECT 3, 1500000000`, Format(`SELECT $1, $2`, level(3), 1500*time.Millisecond))
+	suite.Equal(`SELECT '{18446744073709551615}'`, Format(`SELECT $1`, []uint64{math.MaxUint64}))
+	suite.Equal(Integer, NewArgument(uint64(1)).GetType())
+}
+
+type role string
+
+type flag bool
+
+func (suite *QueryfTestSuite) TestNamedStringsAndBooleans() {
+	suite.Equal(`SELECT 'active', '{"a","b"}', true, '{true,false}'`,
+		Format(`SELECT $1, $2, $3, $4`, role("active"), []role{"a", "b"}, flag(true), []flag{true, false}))
+	suite.Equal(`SELECT 'it''s', 1`, New(WithDialect(MySQL)).Format(`SELECT ?, ?`, role("it's"), flag(true)))
+	r := role("x")
+	suite.Equal(`SELECT 'x'`, Format(`SELECT $1`, &r))
+}
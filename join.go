@@ -0,0 +1,43 @@
+package queryf
+
+import "strings"
+
+// Join merges query fragments into a single statement: the queries are joined
+// with spaces and the arguments appended in order, renumbering the $N
+// placeholders of each fragment after the arguments of the fragments before
+// it, so each fragment can be written as if it was the whole query. Queries
+// built from dynamic conditions can then be formatted or executed at once.
+//
+// Example:
+//
+//	stmt := queryf.Join(
+//		queryf.Statement{Query: "SELECT * FROM users WHERE active = $1", Args: []any{true}},
+//		queryf.Statement{Query: "AND name = $1", Args: []any{"John"}},
+//	)
+//	fmt.Println(stmt.Query, stmt.Args)
+//	// Output: SELECT * FROM users WHERE active = $1 AND name = $2 [true John]
+func Join(parts ...Statement) Statement {
+	return Default().Join(parts...)
+}
+
+// Join merges query fragments written in the Formatter placeholder style,
+// renumbering the :N and @pN placeholders as well. See the package level Join
+// for details.
+func (f *Formatter) Join(parts ...Statement) Statement {
+	style := f.style()
+	queries := make([]string, 0, len(parts))
+	var args []any
+	for _, part := range parts {
+		query := strings.TrimSpace(part.Query)
+		if offset := len(args); offset > 0 && style != Question && style != Named {
+			query = substitute(query, f.syntax(), func(p placeholder) (string, bool) {
+				return placeholderText(style, p.index+offset), true
+			})
+		}
+		if query != "" {
+			queries = append(queries, query)
+		}
+		args = append(args, part.Args...)
+	}
+	return Statement{Query: strings.Join(queries, " "), Args: args}
+}
@@ -0,0 +1,20 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestJoin() {
+	stmt := Join(
+		Statement{Query: `SELECT * FROM users WHERE active = $1`, Args: []any{true}},
+		Statement{Query: ` AND (name = $1 OR email = $2) `, Args: []any{"John", "john@example.com"}},
+		Statement{},
+		Statement{Query: `AND note <> '$1' ORDER BY id LIMIT $1`, Args: []any{10}},
+	)
+	suite.Equal(`SELECT * FROM users WHERE active = $1 AND (name = $2 OR email = $3) AND note <> '$1' ORDER BY id LIMIT $4`, stmt.Query)
+	suite.Equal([]any{true, "John", "john@example.com", 10}, stmt.Args)
+	suite.Equal(`SELECT * FROM users WHERE active = true AND (name = 'John' OR email = 'john@example.com') AND note <> '$1' ORDER BY id LIMIT 10`,
+		Format(stmt.Query, stmt.Args...))
+
+	suite.Equal(Statement{Query: `SELECT ? AND ?`, Args: []any{1, 2}},
+		New(WithPlaceholderStyle(Question)).Join(Statement{Query: `SELECT ?`, Args: []any{1}}, Statement{Query: `AND ?`, Args: []any{2}}))
+	suite.Equal(Statement{Query: `SELECT @p1 AND @p2`, Args: []any{1, 2}},
+		New(WithPlaceholderStyle(AtP)).Join(Statement{Query: `SELECT @p1`, Args: []any{1}}, Statement{Query: `AND @p1`, Args: []any{2}}))
+	suite.Equal(Statement{Query: ``}, Join())
+}
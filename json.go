@@ -0,0 +1,45 @@
+package queryf
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// marshalJSON encodes v as JSON without escaping HTML characters, which would
+// only make the literal harder to read.
+func marshalJSON(v any) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+// WithJSONCast casts the JSON literals of maps, structs and json.RawMessage
+// arguments to typ, e.g. WithJSONCast("jsonb") formats '{"a":1}'::jsonb. Only
+// the Postgres dialect supports casts.
+func WithJSONCast(typ string) Option {
+	return func(f *Formatter) {
+		f.jsonCast = typ
+	}
+}
+
+// formatJSON returns the JSON document s as a string literal.
+func (f *Formatter) formatJSON(s string) string {
+	if f.jsonCast == "" {
+		return f.quote(s)
+	}
+	return f.cast(f.quote(s), f.jsonCast)
+}
+
+func (a *Argument) isRawJSON() bool {
+	_, ok := a.arg.(json.RawMessage)
+	return ok
+}
+
+// formatRawJSON returns the json.RawMessage as is, instead of as bytea.
+func (a *Argument) formatRawJSON() string {
+	return a.formatter.formatJSON(string(a.arg.(json.RawMessage)))
+}
@@ -0,0 +1,125 @@
+package queryf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (suite *QueryfTestSuite) TestMap() {
+	suite.Equal(`SELECT '{"a":1,"b":"x"}'`, Format(`SELECT $1`, map[string]any{"b": "x", "a": 1}))
+	suite.Equal(`SELECT '{"it''s":"<\"quoted\">"}'`, Format(`SELECT $1`, map[string]string{"it's": `<"quoted">`}))
+	suite.Equal(`SELECT '{"a":{"b":["c","d"]}}'`, Format(`SELECT $1`, map[string]any{"a": map[string]any{"b": []string{"c", "d"}}}))
+	suite.Equal(`SELECT '{"1":true}'`, Format(`SELECT $1`, map[int]bool{1: true}))
+	suite.Equal(`SELECT '{"true":1}'`, Format(`SELECT $1`, map[bool]int{true: 1}))
+
+	// Keys are sorted, even when they are converted to strings
+	keys := map[any]any{2: "a", "1": "b", 1: "c", true: "d"}
+	for i := 0; i < 20; i++ {
+		suite.Equal(`SELECT '{"1":"c","1":"b","2":"a","true":"d"}'`, Format(`SELECT $1`, keys))
+	}
+
+	var m map[string]any
+	suite.Equal(`SELECT NULL`, Format(`SELECT $1`, m))
+	suite.Equal(Map, NewArgument(map[string]int{}).GetType())
+}
+
+type address struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type audit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type person struct {
+	audit
+	Name     string   `json:"name"`
+	Nickname string   `json:"nickname,omitempty"`
+	Address  address  `json:"address"`
+	Previous *address `json:"previous"`
+	Tags     []string `json:"tags"`
+	secret   string
+	Ignored  string `json:"-"`
+}
+
+func (suite *QueryfTestSuite) TestStruct() {
+	p := person{
+		audit:   audit{CreatedBy: "admin"},
+		Name:    "O'Brien",
+		Address: address{City: "Lisbon"},
+		Tags:    []string{"a"},
+		secret:  "x",
+		Ignored: "y",
+	}
+	expected := `SELECT '{"created_by":"admin","name":"O''Brien","address":{"city":"Lisbon"},"previous":null,"tags":["a"]}'`
+	suite.Equal(expected, Format(`SELECT $1`, p))
+	suite.Equal(expected, Format(`SELECT $1`, &p))
+	suite.Equal(Struct, NewArgument(p).GetType())
+
+	suite.Equal(`SELECT '{"previous":{"city":"Porto"}}'`, Format(`SELECT $1`, struct {
+		Previous *address `json:"previous"`
+	}{&address{City: "Porto"}}))
+}
+
+// price marshals itself with a pointer receiver, which encoding/json ignores
+// unless the value is addressable.
+type price struct {
+	Cents int64
+}
+
+func (p *price) MarshalJSON() ([]byte, error) {
+	if p.Cents < 0 {
+		return nil, errors.New("negative price")
+	}
+	return []byte(fmt.Sprintf(`"%d.%02d"`, p.Cents/100, p.Cents%100)), nil
+}
+
+// unixTime marshals itself as the number of seconds since the epoch.
+type unixTime struct {
+	time.Time
+}
+
+func (t unixTime) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprint(t.Unix())), nil
+}
+
+type order struct {
+	Total    price    `json:"total"`
+	PlacedAt unixTime `json:"placed_at"`
+}
+
+func (suite *QueryfTestSuite) TestStructMarshalJSON() {
+	o := order{Total: price{1050}, PlacedAt: unixTime{time.Unix(1644451200, 0)}}
+	expected := `SELECT '{"total":"10.50","placed_at":1644451200}'`
+	suite.Equal(expected, Format(`SELECT $1`, o))
+	suite.Equal(expected, Format(`SELECT $1`, &o))
+	suite.Equal(`SELECT '"10.50"'`, Format(`SELECT $1`, price{1050}))
+	suite.Equal(`SELECT '1644451200'`, Format(`SELECT $1`, unixTime{time.Unix(1644451200, 0)}))
+	suite.Equal(`SELECT '<invalid value: negative price>'`, Format(`SELECT $1`, order{Total: price{-1}}))
+
+	// Structs encoding/json rejects keep the encoding of the fields it accepts
+	suite.Equal(`SELECT '{"created_by":"admin","total":"10.50","done":"<unsupported chan bool>"}'`, Format(`SELECT $1`, struct {
+		audit
+		Total price     `json:"total"`
+		Done  chan bool `json:"done"`
+	}{audit{"admin"}, price{1050}, nil}))
+}
+
+func (suite *QueryfTestSuite) TestRawJSON() {
+	raw := json.RawMessage(`{"name":"O'Brien"}`)
+	suite.Equal(`SELECT '{"name":"O''Brien"}', '{"name":"O''Brien"}'`, Format(`SELECT $1, $2`, raw, &raw))
+	suite.Equal(JSON, NewArgument(raw).GetType())
+
+	var null json.RawMessage
+	suite.Equal(`SELECT NULL`, Format(`SELECT $1`, null))
+}
+
+func (suite *QueryfTestSuite) TestJSONCast() {
+	f := New(WithJSONCast("jsonb"))
+	suite.Equal(`SELECT '[1]'::jsonb, '{"a":1}'::jsonb, '{"city":"Lisbon"}'::jsonb`,
+		f.Format(`SELECT $1, $2, $3`, json.RawMessage(`[1]`), map[string]int{"a": 1}, address{City: "Lisbon"}))
+	suite.Equal(`SELECT '[1]'`, New(WithJSONCast("json"), WithDialect(MySQL)).Format(`SELECT ?`, json.RawMessage(`[1]`)))
+}
@@ -0,0 +1,18 @@
+package queryf
+
+import "fmt"
+
+// Lazy returns a fmt.Stringer that formats the query only when String is
+// called, so it costs nothing if the logger discards the message.
+//
+// Example:
+//
+//	log.Debugf("running query: %s", queryf.Lazy(query, args...))
+func Lazy(query string, args ...any) fmt.Stringer {
+	return Query{SQL: query, Args: args}
+}
+
+// String returns the formatted query.
+func (q Query) String() string {
+	return Format(q.SQL, q.Args...)
+}
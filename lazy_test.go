@@ -0,0 +1,24 @@
+package queryf
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+type countingValuer struct {
+	calls *int
+}
+
+func (v countingValuer) Value() (driver.Value, error) {
+	*v.calls++
+	return "x", nil
+}
+
+func (suite *QueryfTestSuite) TestLazy() {
+	var calls int
+	lazy := Lazy(`SELECT $1, $2`, countingValuer{&calls}, 1)
+	suite.Equal(0, calls)
+	suite.Equal(`SELECT 'x', 1`, lazy.String())
+	suite.Equal(`query: SELECT 'x', 1`, fmt.Sprintf("query: %s", lazy))
+	suite.Equal(2, calls)
+}
@@ -0,0 +1,84 @@
+package queryf
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// formatArrayLiteral returns the slice as an array literal of the dialect,
+// followed by its truncation marker.
+func (a *Argument) formatArrayLiteral(dialect ArrayLiteral) string {
+	v := a.getReflectedValue()
+	n, marker := a.formatter.truncateSlice(v.Len())
+	elems := make([]string, n)
+	for i := range elems {
+		elems[i] = a.nested(v.Index(i).Interface()).Format()
+	}
+	return dialect.FormatArray(elems) + marker
+}
+
+// formatStructLiteral returns the exported fields of the struct as a struct
+// literal of the dialect. Fields are named by the dialect struct tag, e.g. the
+// bigquery tag like the BigQuery client does, or by their name.
+func (a *Argument) formatStructLiteral(dialect StructLiteral) string {
+	v := a.getReflectedValue()
+	var names, values []string
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get(dialect.StructTag()), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+		values = append(values, a.nested(v.Field(i).Interface()).Format())
+	}
+	return dialect.FormatStruct(names, values)
+}
+
+// formatMapLiteral returns the map as a map literal of the dialect, e.g.
+// {'a': 1} in CQL, or as a set literal, e.g. {1, 2}, if its values are
+// struct{}. The entries are sorted by key.
+func (a *Argument) formatMapLiteral(dialect MapLiteral) string {
+	v := a.getReflectedValue()
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return lessKey(keys[i], keys[j])
+	})
+	formatted := make([]string, len(keys))
+	for i, key := range keys {
+		formatted[i] = a.nested(key.Interface()).Format()
+	}
+	if elem := v.Type().Elem(); elem.Kind() == reflect.Struct && elem.NumField() == 0 {
+		return dialect.FormatSet(formatted)
+	}
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = a.nested(v.MapIndex(key).Interface()).Format()
+	}
+	return dialect.FormatMap(formatted, values)
+}
+
+// lessKey orders map keys, numerically for numbers and by the string fmt
+// prints for other types.
+func lessKey(x, y reflect.Value) bool {
+	if x.Kind() == reflect.Interface {
+		x = x.Elem()
+	}
+	if y.Kind() == reflect.Interface {
+		y = y.Elem()
+	}
+	switch {
+	case x.CanInt() && y.CanInt():
+		return x.Int() < y.Int()
+	case x.CanUint() && y.CanUint():
+		return x.Uint() < y.Uint()
+	case x.CanFloat() && y.CanFloat():
+		return x.Float() < y.Float()
+	}
+	return fmt.Sprint(x.Interface()) < fmt.Sprint(y.Interface())
+}
@@ -0,0 +1,63 @@
+package queryf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatMarkdown formats the query like Format inside a ```sql fenced code
+// block, followed by a table with the position, type and literal of each
+// argument when there are any, ready to paste into issues and documents.
+//
+// Example:
+//
+//	fmt.Println(queryf.FormatMarkdown("SELECT * FROM users WHERE id = $1", 1))
+//	// Output:
+//	// ```sql
+//	// SELECT * FROM users WHERE id = 1
+//	// ```
+//	//
+//	// | # | Type | Value |
+//	// |---|------|-------|
+//	// | $1 | integer | `1` |
+func FormatMarkdown(query string, args ...any) string {
+	return Default().FormatMarkdown(query, args...)
+}
+
+// FormatMarkdown formats the query as Markdown with the Formatter
+// configuration. See the package level FormatMarkdown for details.
+func (f *Formatter) FormatMarkdown(query string, args ...any) string {
+	plain := *f
+	plain.cache = nil
+	plain.color, plain.keywordColor = false, false
+	formatted := plain.Format(query, args...)
+
+	fence := "```"
+	for strings.Contains(formatted, fence) {
+		fence += "`"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%ssql\n%s\n%s\n", fence, formatted, fence)
+	if len(args) == 0 {
+		return b.String()
+	}
+	b.WriteString("\n| # | Type | Value |\n|---|------|-------|\n")
+	for i, arg := range args {
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", placeholderText(f.style(), i+1), f.NewArgument(arg).GetType(), markdownCode(plain.literal(i+1, arg)))
+	}
+	return b.String()
+}
+
+// markdownCode returns s as a Markdown code span that can be used in a table
+// cell.
+func markdownCode(s string) string {
+	s = strings.ReplaceAll(strings.ReplaceAll(s, "\n", " "), "|", `\|`)
+	ticks := "`"
+	for strings.Contains(s, ticks) {
+		ticks += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return ticks + " " + s + " " + ticks
+	}
+	return ticks + s + ticks
+}
@@ -0,0 +1,14 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestFormatMarkdown() {
+	suite.Equal("```sql\nSELECT * FROM users WHERE id = 1 AND name = 'a|b'\n```\n\n"+
+		"| # | Type | Value |\n|---|------|-------|\n"+
+		"| $1 | integer | `1` |\n| $2 | string | `'a\\|b'` |\n",
+		FormatMarkdown(`SELECT * FROM users WHERE id = $1 AND name = $2`, 1, "a|b"))
+
+	suite.Equal("```sql\nSELECT 1\n```\n", FormatMarkdown(`SELECT 1`))
+	suite.Equal("````sql\nSELECT '```'\n````\n\n| # | Type | Value |\n|---|------|-------|\n| ? | string | ````'```'```` |\n",
+		New(WithPlaceholderStyle(Question), WithColor()).FormatMarkdown(`SELECT ?`, "```"))
+	suite.Contains(New(WithRedactedParams(1)).FormatMarkdown(`SELECT $1`, "x"), "| $1 | string | `'[REDACTED]'` |\n")
+	suite.Equal("`` `a` ``", markdownCode("`a`"))
+}
@@ -0,0 +1,78 @@
+package queryf
+
+import (
+	"expvar"
+	"sync"
+	"time"
+)
+
+// Collector receives the metrics of the queries run through a SlowDB, e.g. to
+// update Prometheus counters and histograms. Queries are identified by their
+// Fingerprint, so queries only differing in their values are counted
+// together; QueryID returns a shorter label for it. Observe is called from the
+// goroutines running the queries, so it must be safe for concurrent use.
+type Collector interface {
+	Observe(fingerprint string, duration time.Duration, err error)
+}
+
+// CollectorFunc is a function implementing Collector.
+type CollectorFunc func(fingerprint string, duration time.Duration, err error)
+
+// Observe calls fn.
+func (fn CollectorFunc) Observe(fingerprint string, duration time.Duration, err error) {
+	fn(fingerprint, duration, err)
+}
+
+// WithMetrics reports every query run through the SlowDB to c, however long
+// it takes.
+//
+// Example:
+//
+//	db := queryf.WrapDB(db, queryf.WithMetrics(queryf.NewExpvarCollector("queries")))
+func WithMetrics(c Collector) DBOption {
+	return func(db *SlowDB) {
+		db.collector = c
+	}
+}
+
+// ExpvarCollector is a Collector publishing the number of queries, the number
+// of failed queries and their total duration in nanoseconds per fingerprint
+// as an expvar map, served by the /debug/vars handler:
+//
+//	{"queries": {"select * from users where id = ?": {"count": 12, "errors": 1, "duration_ns": 5230000}}}
+type ExpvarCollector struct {
+	mu   sync.Mutex
+	vars *expvar.Map
+}
+
+// NewExpvarCollector returns an ExpvarCollector publishing its metrics under
+// name. Like expvar.NewMap, it panics if name is already published.
+func NewExpvarCollector(name string) *ExpvarCollector {
+	return &ExpvarCollector{vars: expvar.NewMap(name)}
+}
+
+// Observe adds the query to the metrics of its fingerprint.
+func (c *ExpvarCollector) Observe(fingerprint string, duration time.Duration, err error) {
+	stats := c.stats(fingerprint)
+	stats.Add("count", 1)
+	if err != nil {
+		stats.Add("errors", 1)
+	}
+	stats.Add("duration_ns", duration.Nanoseconds())
+}
+
+// stats returns the metrics of the fingerprint, creating them on its first
+// query.
+func (c *ExpvarCollector) stats(fingerprint string) *expvar.Map {
+	if stats, ok := c.vars.Get(fingerprint).(*expvar.Map); ok {
+		return stats
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stats, ok := c.vars.Get(fingerprint).(*expvar.Map); ok {
+		return stats
+	}
+	stats := new(expvar.Map)
+	c.vars.Set(fingerprint, stats)
+	return stats
+}
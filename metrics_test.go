@@ -0,0 +1,42 @@
+package queryf
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"time"
+)
+
+func (suite *QueryfTestSuite) TestWrapDBMetrics() {
+	fake, sqlDB := newFakeDB()
+	defer sqlDB.Close()
+	var fingerprints []string
+	var failed int
+	collector := CollectorFunc(func(fingerprint string, duration time.Duration, err error) {
+		fingerprints = append(fingerprints, fingerprint)
+		if err != nil {
+			failed++
+		}
+	})
+
+	db := WrapDB(sqlDB, WithMetrics(collector), WithSlowThreshold(time.Hour))
+	_, err := db.ExecContext(context.Background(), `DELETE FROM t WHERE id = $1`, 1)
+	suite.Nil(err)
+	_, err = db.Exec(`DELETE FROM t WHERE id = 2`)
+	suite.Nil(err)
+	fake.err = errors.New("boom")
+	_, err = db.Exec(`UPDATE t SET a = 1`)
+	suite.EqualError(err, "boom")
+
+	suite.Equal([]string{`delete from t where id = ?`, `delete from t where id = ?`, `update t set a = ?`}, fingerprints)
+	suite.Equal(1, failed)
+}
+
+func (suite *QueryfTestSuite) TestExpvarCollector() {
+	c := NewExpvarCollector("queryf_test_queries")
+	c.Observe(`select ?`, 2*time.Millisecond, nil)
+	c.Observe(`select ?`, 3*time.Millisecond, errors.New("boom"))
+
+	stats := expvar.Get("queryf_test_queries").(*expvar.Map).Get(`select ?`).(*expvar.Map)
+	suite.Equal(`{"count": 2, "duration_ns": 5000000, "errors": 1}`, stats.String())
+}
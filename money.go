@@ -0,0 +1,121 @@
+package queryf
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// goMoneyPkgPath is the package of Rhymond/go-money, whose types are detected
+// without importing it.
+const goMoneyPkgPath = "github.com/Rhymond/go-money"
+
+// WithMinorUnits formats the integers of the type of sample, e.g. a type Cents
+// int64, as amounts in minor units with the given number of fraction digits:
+// WithMinorUnits(Cents(0), 2) formats Cents(1234) as 12.34.
+func WithMinorUnits(sample any, digits int) Option {
+	return func(f *Formatter) {
+		// Formatters derived with With share the map of their parent.
+		units := make(map[reflect.Type]int, len(f.minorUnits)+1)
+		for t, d := range f.minorUnits {
+			units[t] = d
+		}
+		units[reflect.TypeOf(sample)] = digits
+		f.minorUnits = units
+	}
+}
+
+// WithMoneyCast casts money amounts to the Postgres money type, e.g.
+// 12.34::money, instead of formatting them as numeric literals. The cast is
+// from a numeric, which unlike a string such as '$12.34' doesn't depend on the
+// lc_monetary setting of the server.
+func WithMoneyCast() Option {
+	return func(f *Formatter) {
+		f.moneyCast = true
+	}
+}
+
+// isMoney reports whether the argument is a money amount: a go-money Money or
+// an integer type registered with WithMinorUnits.
+func (a *Argument) isMoney() bool {
+	t := a.getReflectedType()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if isNamedType(t, goMoneyPkgPath, "Money") {
+		return hasGoMoneyMethods(reflect.PointerTo(t))
+	}
+	_, ok := a.formatter.minorUnits[a.getReflectedType()]
+	return ok
+}
+
+// hasGoMoneyMethods reports whether t has the Amount and Currency methods of
+// go-money, which formatMoney calls.
+func hasGoMoneyMethods(t reflect.Type) bool {
+	amount, ok := t.MethodByName("Amount")
+	if !ok || amount.Type.NumIn() != 1 || amount.Type.NumOut() != 1 || amount.Type.Out(0).Kind() != reflect.Int64 {
+		return false
+	}
+	currency, ok := t.MethodByName("Currency")
+	if !ok || currency.Type.NumIn() != 1 || currency.Type.NumOut() != 1 {
+		return false
+	}
+	out := currency.Type.Out(0)
+	if out.Kind() != reflect.Ptr || out.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	fraction, ok := out.Elem().FieldByName("Fraction")
+	return ok && fraction.Type.Kind() == reflect.Int
+}
+
+// formatMoney returns the amount as a numeric literal, always with a dot as
+// the decimal point, cast to money if WithMoneyCast is given.
+func (a *Argument) formatMoney() string {
+	amount, digits := a.minorUnits()
+	literal := formatMinorUnits(amount, digits)
+	if a.formatter.moneyCast {
+		return a.formatter.cast(literal, "money")
+	}
+	return literal
+}
+
+// minorUnits returns the amount of the argument in minor units, as a decimal
+// integer, and the number of digits of its fraction. Unsigned amounts are kept
+// unsigned, so they don't overflow above math.MaxInt64. The methods of
+// go-money have pointer receivers, so values are copied to be addressable.
+func (a *Argument) minorUnits() (string, int) {
+	v := a.getReflectedValue()
+	if digits, ok := a.formatter.minorUnits[v.Type()]; ok {
+		if v.CanInt() {
+			return strconv.FormatInt(v.Int(), 10), digits
+		}
+		return strconv.FormatUint(v.Uint(), 10), digits
+	}
+	if v.Kind() != reflect.Ptr {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		v = ptr
+	}
+	amount := strconv.FormatInt(v.MethodByName("Amount").Call(nil)[0].Int(), 10)
+	currency := v.MethodByName("Currency").Call(nil)[0]
+	if currency.IsNil() {
+		return amount, 0
+	}
+	return amount, int(currency.Elem().FieldByName("Fraction").Int())
+}
+
+// formatMinorUnits returns the decimal of the integer amount divided by
+// 10^digits, e.g. 12.34 for 1234 and 2 digits.
+func formatMinorUnits(amount string, digits int) string {
+	s, sign := amount, ""
+	if strings.HasPrefix(amount, "-") {
+		s, sign = amount[1:], "-"
+	}
+	if digits <= 0 {
+		return sign + s
+	}
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
@@ -0,0 +1,63 @@
+package queryf
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+type cents int64
+
+type satoshis uint64
+
+func (suite *QueryfTestSuite) TestMinorUnits() {
+	f := New(WithMinorUnits(cents(0), 2), WithMinorUnits(satoshis(0), 8))
+	suite.Equal(`SELECT 12.34, -0.05, 0.00000001`, f.Format(`SELECT $1, $2, $3`, cents(1234), cents(-5), satoshis(1)))
+	amount := cents(1234)
+	suite.Equal(`SELECT 12.34, NULL`, f.Format(`SELECT $1, $2`, &amount, (*cents)(nil)))
+	suite.Equal(`SELECT 184467440737.09551615`, f.Format(`SELECT $1`, satoshis(math.MaxUint64)))
+	suite.Equal(`SELECT '{1.00,2.50}'`, f.Format(`SELECT $1`, []cents{100, 250}))
+	suite.Equal(`SELECT 1234`, Format(`SELECT $1`, cents(1234)))
+	suite.Equal(Money, (&Argument{arg: cents(1), formatter: f}).GetType())
+
+	suite.Equal(`SELECT 12.34::money`, f.With(WithMoneyCast()).Format(`SELECT $1`, cents(1234)))
+	suite.Equal(`SELECT 12.34`, f.With(WithMoneyCast(), WithDialect(MySQL)).Format(`SELECT ?`, cents(1234)))
+	suite.Equal(`SELECT 1234`, f.With(WithMinorUnits(cents(0), 0)).Format(`SELECT $1`, cents(1234)))
+	suite.Equal(`SELECT 12.34`, f.Format(`SELECT $1`, cents(1234)))
+}
+
+func (suite *QueryfTestSuite) TestFormatMinorUnits() {
+	suite.Equal("0.00", formatMinorUnits("0", 2))
+	suite.Equal("-12.34", formatMinorUnits("-1234", 2))
+	suite.Equal("-0.05", formatMinorUnits("-5", 2))
+	suite.Equal("-92233720368547758.08", formatMinorUnits(strconv.FormatInt(math.MinInt64, 10), 2))
+	suite.Equal("5", formatMinorUnits("5", 0))
+}
+
+// goMoney has the methods of a go-money Money.
+type goMoney struct {
+	amount   int64
+	currency *goMoneyCurrency
+}
+
+type goMoneyCurrency struct {
+	Code     string
+	Fraction int
+}
+
+func (m *goMoney) Amount() int64 { return m.amount }
+
+func (m *goMoney) Currency() *goMoneyCurrency { return m.currency }
+
+func (suite *QueryfTestSuite) TestGoMoney() {
+	suite.True(hasGoMoneyMethods(reflect.TypeOf(&goMoney{})))
+	suite.False(hasGoMoneyMethods(reflect.TypeOf(new(cents))))
+
+	m := goMoney{amount: 1234, currency: &goMoneyCurrency{Code: "USD", Fraction: 2}}
+	amount, digits := (&Argument{arg: m, formatter: Default()}).minorUnits()
+	suite.Equal("1234", amount)
+	suite.Equal(2, digits)
+	amount, digits = (&Argument{arg: &goMoney{amount: 5}, formatter: Default()}).minorUnits()
+	suite.Equal("5", amount)
+	suite.Equal(0, digits)
+}
@@ -0,0 +1,60 @@
+package queryf
+
+// FormatNamed will return the query with the named arguments formatted.
+// Both :name (sqlx) and @name (SQL Server) placeholders are replaced by the
+// value with the same name in arg, a map with string keys or a struct named
+// like BindNamed does. Placeholders without a matching argument are left
+// untouched.
+//
+// Example:
+//
+//	query := "SELECT * FROM users WHERE id = :id AND name = @name"
+//	args := map[string]any{"id": 1, "name": "John"}
+//	fmt.Println(FormatNamed(query, args))
+//	// Output: SELECT * FROM users WHERE id = 1 AND name = 'John'
+func FormatNamed(query string, arg any) string {
+	return Default().FormatNamed(query, arg)
+}
+
+// FormatNamed will return the query with the named arguments formatted using
+// the Formatter configuration. See the package level FormatNamed for details.
+func (f *Formatter) FormatNamed(query string, arg any) string {
+	args, _ := f.namedValues(arg)
+	return substitute(query, syntax{style: Named, backslash: f.syntax().backslash}, func(p placeholder) (string, bool) {
+		arg, ok := args[p.name]
+		if !ok {
+			return "", false
+		}
+		return f.formatArg(0, arg), true
+	})
+}
+
+// isNamedPrefix reports whether the : or @ at position i starts a named
+// placeholder, skipping casts (::) and SQL Server variables (@@).
+func isNamedPrefix(query string, i int) bool {
+	c := query[i]
+	if c != ':' && c != '@' {
+		return false
+	}
+	if i > 0 && query[i-1] == c {
+		return false
+	}
+	return i+1 < len(query) && isNameStart(query[i+1])
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
+
+// namedEnd returns the end of the name starting at i. Names may have dots
+// followed by more names, e.g. address.city for the fields of nested structs.
+func namedEnd(query string, i int) int {
+	for i < len(query) && (isNameChar(query[i]) || query[i] == '.' && i+1 < len(query) && isNameStart(query[i+1])) {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,16 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestFormatNamed() {
+	args := map[string]any{"id": 1, "name": "John"}
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND name = 'John'`,
+		FormatNamed(`SELECT * FROM users WHERE id = :id AND name = @name`, args))
+
+	// Unknown names are kept
+	suite.Equal(`SELECT 1, :missing`, FormatNamed(`SELECT :id, :missing`, args))
+
+	// Casts and SQL Server variables are not placeholders
+	suite.Equal(`SELECT 1::text, @@ROWCOUNT`, FormatNamed(`SELECT :id::text, @@ROWCOUNT`, args))
+	suite.Equal(`SELECT now()::date`, FormatNamed(`SELECT now()::date`, map[string]any{"date": 1}))
+
+	suite.Equal(`SELECT null`, New(WithNullLiteral("null")).FormatNamed(`SELECT :v`, map[string]any{"v": nil}))
+}
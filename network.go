@@ -0,0 +1,36 @@
+package queryf
+
+import (
+	"net"
+	"net/netip"
+)
+
+func (a *Argument) isNetwork() bool {
+	switch a.arg.(type) {
+	case net.IP, net.IPNet, netip.Addr, netip.Prefix:
+		return true
+	}
+	return false
+}
+
+// formatNetwork returns addresses as inet literals and networks as cidr
+// literals, e.g. '10.0.0.1'::inet and '192.168.1.0/24'::cidr.
+func (a *Argument) formatNetwork() string {
+	switch v := a.arg.(type) {
+	case net.IP:
+		return a.formatter.cast(a.formatter.quote(v.String()), "inet")
+	case net.IPNet:
+		return a.formatter.cast(a.formatter.quote(v.String()), "cidr")
+	case netip.Addr:
+		if !v.IsValid() {
+			return a.formatNull()
+		}
+		return a.formatter.cast(a.formatter.quote(v.String()), "inet")
+	case netip.Prefix:
+		if !v.IsValid() {
+			return a.formatNull()
+		}
+		return a.formatter.cast(a.formatter.quote(v.String()), "cidr")
+	}
+	return a.formatNull()
+}
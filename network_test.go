@@ -0,0 +1,20 @@
+package queryf
+
+import (
+	"net"
+	"net/netip"
+)
+
+func (suite *QueryfTestSuite) TestNetwork() {
+	_, network, err := net.ParseCIDR("192.168.1.0/24")
+	suite.Nil(err)
+	suite.Equal(`SELECT '10.0.0.1'::inet, '::1'::inet, '192.168.1.0/24'::cidr, '192.168.1.0/24'::cidr`,
+		Format(`SELECT $1, $2, $3, $4`, net.ParseIP("10.0.0.1"), net.ParseIP("::1"), network, *network))
+	suite.Equal(`SELECT '10.0.0.1'::inet, '10.0.0.0/8'::cidr, NULL, NULL`,
+		Format(`SELECT $1, $2, $3, $4`, netip.MustParseAddr("10.0.0.1"), netip.MustParsePrefix("10.0.0.0/8"), netip.Addr{}, netip.Prefix{}))
+
+	var ip net.IP
+	suite.Equal(`SELECT NULL`, Format(`SELECT $1`, ip))
+	suite.Equal(`SELECT '10.0.0.1'`, New(WithDialect(MySQL)).Format(`SELECT ?`, net.ParseIP("10.0.0.1")))
+	suite.Equal(Network, NewArgument(netip.Addr{}).GetType())
+}
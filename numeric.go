@@ -0,0 +1,107 @@
+package queryf
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// decimalPkgPath is the package of shopspring/decimal, whose types are detected
+// without importing it.
+const decimalPkgPath = "github.com/shopspring/decimal"
+
+// isNumeric reports whether the argument is an arbitrary-precision number:
+// big.Int, big.Float, big.Rat (or pointers to them), decimal.Decimal,
+// decimal.NullDecimal or pgtype.Numeric.
+func (a *Argument) isNumeric() bool {
+	if a.bigNumber() != nil || a.isPgtypeNumeric() {
+		return true
+	}
+	t := a.getReflectedType()
+	return isNamedType(t, decimalPkgPath, "Decimal") || isNamedType(t, decimalPkgPath, "NullDecimal")
+}
+
+// bigNumber returns the argument as a *big.Int, *big.Float or *big.Rat, or nil
+// if it is none of them. Their String methods have pointer receivers, so
+// values are copied to be addressable.
+func (a *Argument) bigNumber() any {
+	switch a.arg.(type) {
+	case *big.Int, *big.Float, *big.Rat:
+		return a.arg
+	case big.Int, big.Float, big.Rat:
+		ptr := reflect.New(a.getReflectedType())
+		ptr.Elem().Set(a.getReflectedValue())
+		return ptr.Interface()
+	}
+	return nil
+}
+
+func (a *Argument) formatNumeric() string {
+	if a.isPgtypeNumeric() {
+		return a.formatPgtypeNumeric()
+	}
+	switch n := a.bigNumber().(type) {
+	case *big.Int:
+		return n.String()
+	case *big.Float:
+		return a.formatNumber(n.Text('f', -1))
+	case *big.Rat:
+		return a.formatRat(n)
+	}
+	rv := a.getReflectedValue()
+	if a.getReflectedType().Name() == "NullDecimal" {
+		if !rv.FieldByName("Valid").Bool() {
+			return a.formatNull()
+		}
+		rv = rv.FieldByName("Decimal")
+	}
+	return a.formatNumber(fmt.Sprint(rv.Interface()))
+}
+
+// ratDigits are the decimal digits of the quotients written in COPY data, as
+// many as Postgres gives to the division of small numerics.
+const ratDigits = 20
+
+// formatRat returns the exact decimal of r when it has one, or the division of
+// its numerator by its denominator otherwise, e.g. (1::numeric/3). COPY data
+// can't hold expressions, so it gets the decimal rounded to ratDigits instead.
+func (a *Argument) formatRat(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	denom := new(big.Int).Set(r.Denom())
+	digits := 0
+	for _, factor := range []*big.Int{big.NewInt(2), big.NewInt(5)} {
+		for m := new(big.Int); m.Mod(denom, factor).Sign() == 0; {
+			denom.Div(denom, factor)
+			digits++
+		}
+	}
+	if denom.Cmp(big.NewInt(1)) == 0 {
+		return strings.TrimRight(r.FloatString(digits), "0")
+	}
+	if a.formatter.copyText {
+		return r.FloatString(ratDigits)
+	}
+	return "(" + a.formatter.cast(r.Num().String(), "numeric") + "/" + r.Denom().String() + ")"
+}
+
+// formatNumber returns s as a numeric literal, or as a quoted literal cast to
+// numeric if it isn't a plain decimal number, e.g. 'NaN'::numeric.
+func (a *Argument) formatNumber(s string) string {
+	if !isDecimal(s) {
+		return a.formatter.cast(a.formatter.quote(s), "numeric")
+	}
+	return s
+}
+
+// isDecimal reports whether s is a plain decimal number, e.g. -12.34.
+func isDecimal(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	integer, fraction, hasPoint := strings.Cut(s, ".")
+	if integer == "" || strings.Trim(integer, "0123456789") != "" {
+		return false
+	}
+	return !hasPoint || (fraction != "" && strings.Trim(fraction, "0123456789") == "")
+}
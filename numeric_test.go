@@ -0,0 +1,39 @@
+package queryf
+
+import (
+	"math/big"
+
+	"github.com/shopspring/decimal"
+)
+
+func (suite *QueryfTestSuite) TestBigNumbers() {
+	i, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	f, _ := new(big.Float).SetPrec(200).SetString("12345678901234567890.123456789")
+	suite.Equal(`SELECT 123456789012345678901234567890, 123456789012345678901234567890`, Format(`SELECT $1, $2`, i, *i))
+	suite.Equal(`SELECT 12345678901234567890.123456789`, Format(`SELECT $1`, f))
+	suite.Equal(`SELECT '+Inf'::numeric`, Format(`SELECT $1`, new(big.Float).SetInf(false)))
+	suite.Equal(`SELECT 3, 0.125, -2.5, (1::numeric/3)`, Format(`SELECT $1, $2, $3, $4`,
+		big.NewRat(6, 2), big.NewRat(1, 8), big.NewRat(-5, 2), big.NewRat(1, 3)))
+	suite.Equal(`SELECT (1/3)`, New(WithDialect(MySQL)).Format(`SELECT ?`, big.NewRat(1, 3)))
+	suite.Equal(Numeric, NewArgument(i).GetType())
+
+	var nilInt *big.Int
+	suite.Equal(`SELECT NULL`, Format(`SELECT $1`, nilInt))
+}
+
+func (suite *QueryfTestSuite) TestDecimal() {
+	d := decimal.RequireFromString("12345678901234567890.0123456789")
+	suite.Equal(`SELECT 12345678901234567890.0123456789, 12345678901234567890.0123456789`, Format(`SELECT $1, $2`, d, &d))
+	suite.Equal(`SELECT -0.5, NULL`, Format(`SELECT $1, $2`,
+		decimal.NewNullDecimal(decimal.RequireFromString("-0.5")), decimal.NullDecimal{}))
+	suite.Equal(Numeric, NewArgument(d).GetType())
+}
+
+func (suite *QueryfTestSuite) TestIsDecimal() {
+	for _, s := range []string{"1", "-1", "1.5", "0.005", "-12.340"} {
+		suite.True(isDecimal(s), s)
+	}
+	for _, s := range []string{"", "-", "1.", ".5", "1e5", "NaN", "Infinity", "1.2.3"} {
+		suite.False(isDecimal(s), s)
+	}
+}
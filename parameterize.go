@@ -0,0 +1,178 @@
+package queryf
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrHasPlaceholders is returned by Parameterize when the SQL already has
+// placeholders, which would clash with the ones it adds.
+var ErrHasPlaceholders = errors.New("queryf: query already has placeholders")
+
+// Parameterize is the inverse of Format: it replaces the string, number,
+// boolean and NULL literals of the SQL with placeholders and returns them as
+// arguments. NULL, TRUE and FALSE are kept after IS and NOT, where they aren't
+// values, and so are prefixed string literals such as X'00', B'01', N'John' and
+// U&'d\0061t', whose prefix can't be bound.
+//
+// Example:
+//
+//	query, args, _ := queryf.Parameterize("SELECT * FROM users WHERE id = 1 AND name = 'John'")
+//	fmt.Println(query, args)
+//	// Output: SELECT * FROM users WHERE id = $1 AND name = $2 [1 John]
+func Parameterize(sql string) (query string, args []any, err error) {
+	return Default().Parameterize(sql)
+}
+
+// Parameterize replaces the literals of the SQL with placeholders in the
+// Formatter placeholder style. See the package level Parameterize for details.
+func (f *Formatter) Parameterize(sql string) (query string, args []any, err error) {
+	p := &parameterizer{style: f.style()}
+	t := newTokenizer(sql, f.syntax())
+	for tok, ok := t.next(); ok; tok, ok = t.next() {
+		text := sql[tok.start:tok.end]
+		switch {
+		case tok.unterminated:
+			return "", nil, fmt.Errorf("%w at offset %d", ErrUnterminated, tok.start)
+		case tok.kind == tokenPlaceholder:
+			return "", nil, fmt.Errorf("%w: %s", ErrHasPlaceholders, text)
+		case tok.kind == tokenText:
+			p.text(text)
+			continue
+		case tok.kind == tokenString && hasStringPrefix(sql, tok.start):
+			p.WriteString(text)
+		case tok.kind == tokenString && text[0] == '\'' && t.backslash:
+			p.bind(unescapeString(text[1 : len(text)-1]))
+		case tok.kind == tokenString && text[0] == '\'':
+			p.bind(quoteUnescaper.Replace(text[1 : len(text)-1]))
+		case tok.kind == tokenString:
+			p.bind(unescapeString(text[2 : len(text)-1]))
+		case tok.kind == tokenDollarQuoted:
+			tag := strings.IndexByte(text[1:], '$') + 2
+			p.bind(text[tag : len(text)-tag])
+		default:
+			p.WriteString(text)
+		}
+		p.prevWord = ""
+	}
+	return p.String(), p.args, nil
+}
+
+// parameterizer builds the parameterized query.
+type parameterizer struct {
+	strings.Builder
+	style PlaceholderStyle
+	args  []any
+	// prevWord is the last word written, lowercased, to tell IS NULL apart
+	// from NULL values.
+	prevWord string
+}
+
+// bind writes a placeholder for the value.
+func (p *parameterizer) bind(value any) {
+	p.args = append(p.args, value)
+	p.WriteString(placeholderText(p.style, len(p.args)))
+}
+
+// text writes the SQL text, binding its number and keyword literals.
+func (p *parameterizer) text(s string) {
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case isNameStart(c) || c >= 0x80:
+			end := i + 1
+			for end < len(s) && (isNameChar(s[end]) || s[end] == '$' || s[end] >= 0x80) {
+				end++
+			}
+			p.word(s[i:end], i > 0 && s[i-1] == '.')
+			i = end
+		case isDigit(c) || (c == '.' && i+1 < len(s) && isDigit(s[i+1])):
+			end := numberEnd(s, i)
+			if end < len(s) && isNameChar(s[end]) {
+				for end < len(s) && isNameChar(s[end]) {
+					end++
+				}
+				p.WriteString(s[i:end])
+			} else if v, ok := parseNumber(s[i:end]); ok {
+				p.bind(v)
+			} else {
+				p.WriteString(s[i:end])
+			}
+			p.prevWord = ""
+			i = end
+		default:
+			if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+				p.prevWord = ""
+			}
+			p.WriteByte(c)
+			i++
+		}
+	}
+}
+
+// word writes a word, binding the NULL, TRUE and FALSE keywords when they are
+// values.
+func (p *parameterizer) word(w string, qualified bool) {
+	lower := strings.ToLower(w)
+	keyword := !qualified && p.prevWord != "is" && p.prevWord != "not"
+	switch {
+	case keyword && lower == "null":
+		p.bind(nil)
+	case keyword && lower == "true":
+		p.bind(true)
+	case keyword && lower == "false":
+		p.bind(false)
+	default:
+		p.WriteString(w)
+	}
+	p.prevWord = lower
+}
+
+// hasStringPrefix reports whether the string literal starting at i has an X,
+// B, N or U& prefix, e.g. X'00' or N'John'.
+func hasStringPrefix(sql string, i int) bool {
+	prefix := 1
+	if i >= 2 && sql[i-1] == '&' {
+		prefix = 2
+	}
+	if i < prefix || (i > prefix && isNameChar(sql[i-prefix-1])) {
+		return false
+	}
+	switch strings.ToUpper(sql[i-prefix : i]) {
+	case "X", "B", "N", "U&":
+		return true
+	}
+	return false
+}
+
+// numberEnd returns the end of the number starting at i, including its
+// fraction and exponent.
+func numberEnd(s string, i int) int {
+	for i < len(s) && (isDigit(s[i]) || s[i] == '.') {
+		i++
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if j < len(s) && isDigit(s[j]) {
+			for i = j; i < len(s) && isDigit(s[i]); i++ {
+			}
+		}
+	}
+	return i
+}
+
+// parseNumber returns s as an int64, or as a float64 if it has a fraction or
+// an exponent.
+func parseNumber(s string) (any, bool) {
+	if !strings.ContainsAny(s, ".eE") {
+		n, err := strconv.ParseInt(s, 10, 64)
+		return n, err == nil
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	return n, err == nil
+}
@@ -0,0 +1,46 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestParameterize() {
+	query, args, err := Parameterize(`SELECT * FROM users WHERE id = 1 AND name = 'it''s' AND score > -1.5e3 AND active = TRUE`)
+	suite.Nil(err)
+	suite.Equal(`SELECT * FROM users WHERE id = $1 AND name = $2 AND score > -$3 AND active = $4`, query)
+	suite.Equal([]any{int64(1), "it's", 1500.0, true}, args)
+
+	query, args, err = Parameterize(`UPDATE t1 SET a = NULL, b = E'x\ny', c = $$body$$, d = '2022-01-01'::date WHERE e IS NULL AND f IS NOT TRUE AND t1.null = false`)
+	suite.Nil(err)
+	suite.Equal(`UPDATE t1 SET a = $1, b = $2, c = $3, d = $4::date WHERE e IS NULL AND f IS NOT TRUE AND t1.null = $5`, query)
+	suite.Equal([]any{nil, "x\ny", "body", "2022-01-01", false}, args)
+
+	query, args, err = New(WithPlaceholderStyle(Question)).Parameterize(`SELECT "col 1", x2 FROM t -- 3 rows
+		LIMIT 10 OFFSET .5`)
+	suite.Nil(err)
+	suite.Equal("SELECT \"col 1\", x2 FROM t -- 3 rows\n\t\tLIMIT ? OFFSET ?", query)
+	suite.Equal([]any{int64(10), 0.5}, args)
+
+	// Parameterize is the inverse of Format
+	sql := `SELECT * FROM t WHERE a = 'x' AND b = 42 AND c = NULL`
+	query, args, err = Parameterize(sql)
+	suite.Nil(err)
+	suite.Equal(sql, Format(query, args...))
+}
+
+func (suite *QueryfTestSuite) TestParameterizeStringPrefixes() {
+	query, args, err := Parameterize(`SELECT x'00', B'0101', N'John', u&'d\0061t', 'a'`)
+	suite.Nil(err)
+	suite.Equal(`SELECT x'00', B'0101', N'John', u&'d\0061t', $1`, query)
+	suite.Equal([]any{"a"}, args)
+
+	query, args, err = New(WithDialect(SQLServer)).Parameterize(`SELECT * FROM users WHERE name = N'John' AND id = 1`)
+	suite.Nil(err)
+	suite.Equal(`SELECT * FROM users WHERE name = N'John' AND id = @p1`, query)
+	suite.Equal([]any{int64(1)}, args)
+}
+
+func (suite *QueryfTestSuite) TestParameterizeErrors() {
+	_, _, err := Parameterize(`SELECT * FROM t WHERE id = $1`)
+	suite.ErrorIs(err, ErrHasPlaceholders)
+	suite.EqualError(err, "queryf: query already has placeholders: $1")
+
+	_, _, err = Parameterize(`SELECT 'unterminated`)
+	suite.ErrorIs(err, ErrUnterminated)
+}
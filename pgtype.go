@@ -0,0 +1,86 @@
+package queryf
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// pgtypePkgPath is the package of pgx v5 types, which are detected without
+// importing it. Most of them implement driver.Valuer and need nothing else.
+const pgtypePkgPath = "github.com/jackc/pgx/v5/pgtype"
+
+// isNamedType reports whether t is the type name of package pkgPath, or an
+// instance of it if name is a generic type.
+func isNamedType(t reflect.Type, pkgPath, name string) bool {
+	return t.PkgPath() == pkgPath && (t.Name() == name || strings.HasPrefix(t.Name(), name+"["))
+}
+
+// isPgtypeNumeric reports whether the argument is a pgtype.Numeric, whose
+// Value is a string that must not be quoted.
+func (a *Argument) isPgtypeNumeric() bool {
+	return isNamedType(a.getReflectedType(), pgtypePkgPath, "Numeric")
+}
+
+// isPgtypeArray reports whether the argument is a pgtype.Array[T].
+func (a *Argument) isPgtypeArray() bool {
+	return isNamedType(a.getReflectedType(), pgtypePkgPath, "Array")
+}
+
+func (a *Argument) formatPgtypeNumeric() string {
+	v, err := a.arg.(driver.Valuer).Value()
+	if err != nil {
+		return a.formatter.quote(fmt.Sprintf("<invalid value: %v>", err))
+	}
+	if s, ok := v.(string); ok {
+		return a.formatNumber(s)
+	}
+	return a.nested(v).Format()
+}
+
+// formatPgtypeArray formats the elements of a pgtype.Array[T], reshaped into
+// nested slices when the array has more than one dimension.
+func (a *Argument) formatPgtypeArray() string {
+	rv := a.getReflectedValue()
+	if !rv.FieldByName("Valid").Bool() {
+		return a.formatNull()
+	}
+	elements := rv.FieldByName("Elements")
+	dims := rv.FieldByName("Dims")
+	lengths := make([]int, dims.Len())
+	for i := range lengths {
+		lengths[i] = int(dims.Index(i).FieldByName("Length").Int())
+	}
+	if len(lengths) <= 1 || !fitsDims(elements.Len(), lengths) {
+		return a.unwrap(elements.Interface()).formatSlice()
+	}
+	return a.unwrap(reshape(elements, lengths)).formatSlice()
+}
+
+// fitsDims reports whether n elements can be reshaped into dimensions with the
+// given lengths. Arrays with an empty dimension, or whose elements don't match
+// their dimensions, are formatted flat instead.
+func fitsDims(n int, lengths []int) bool {
+	product := 1
+	for _, length := range lengths {
+		if length <= 0 {
+			return false
+		}
+		product *= length
+	}
+	return product == n
+}
+
+// reshape splits the flat elements into nested slices with the given lengths.
+func reshape(elements reflect.Value, lengths []int) any {
+	if len(lengths) == 1 {
+		return elements.Interface()
+	}
+	size := elements.Len() / lengths[0]
+	nested := make([]any, lengths[0])
+	for i := range nested {
+		nested[i] = reshape(elements.Slice(i*size, (i+1)*size), lengths[1:])
+	}
+	return nested
+}
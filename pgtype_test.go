@@ -0,0 +1,55 @@
+package queryf
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (suite *QueryfTestSuite) TestPgtype() {
+	t := time.Date(2022, 2, 10, 13, 45, 0, 0, time.UTC)
+	suite.Equal(`SELECT 'John', NULL, 5, '2022-02-10T13:45:00Z', '123e4567-e89b-12d3-a456-426614174000'`,
+		Format(`SELECT $1, $2, $3, $4, $5`,
+			pgtype.Text{String: "John", Valid: true},
+			pgtype.Text{},
+			pgtype.Int8{Int64: 5, Valid: true},
+			pgtype.Timestamptz{Time: t, Valid: true},
+			pgtype.UUID{Bytes: [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}, Valid: true},
+		))
+}
+
+func (suite *QueryfTestSuite) TestPgtypeNumeric() {
+	suite.Equal(`SELECT 123.45, -0.005, NULL, 'NaN'::numeric, '-Infinity'::numeric`,
+		Format(`SELECT $1, $2, $3, $4, $5`,
+			pgtype.Numeric{Int: big.NewInt(12345), Exp: -2, Valid: true},
+			pgtype.Numeric{Int: big.NewInt(-5), Exp: -3, Valid: true},
+			pgtype.Numeric{},
+			pgtype.Numeric{NaN: true, Valid: true},
+			pgtype.Numeric{InfinityModifier: pgtype.NegativeInfinity, Valid: true},
+		))
+	suite.Equal(Numeric, NewArgument(pgtype.Numeric{}).GetType())
+}
+
+func (suite *QueryfTestSuite) TestPgtypeArray() {
+	suite.Equal(`SELECT '{"a","b"}', NULL`, Format(`SELECT $1, $2`,
+		pgtype.Array[string]{Elements: []string{"a", "b"}, Dims: []pgtype.ArrayDimension{{Length: 2, LowerBound: 1}}, Valid: true},
+		pgtype.Array[string]{},
+	))
+	suite.Equal(`SELECT '{{1,2,3},{4,5,6}}'`, Format(`SELECT $1`, pgtype.Array[int32]{
+		Elements: []int32{1, 2, 3, 4, 5, 6},
+		Dims:     []pgtype.ArrayDimension{{Length: 2, LowerBound: 1}, {Length: 3, LowerBound: 1}},
+		Valid:    true,
+	}))
+	suite.Equal(`SELECT '{}'`, Format(`SELECT $1`, pgtype.Array[int32]{
+		Elements: []int32{},
+		Dims:     []pgtype.ArrayDimension{{Length: 0, LowerBound: 1}, {Length: 3, LowerBound: 1}},
+		Valid:    true,
+	}))
+	suite.Equal(`SELECT '{1,2,3}'`, Format(`SELECT $1`, pgtype.Array[int32]{
+		Elements: []int32{1, 2, 3},
+		Dims:     []pgtype.ArrayDimension{{Length: 2, LowerBound: 1}, {Length: 2, LowerBound: 1}},
+		Valid:    true,
+	}))
+	suite.Equal(`SELECT '{1,NULL}'`, Format(`SELECT $1`, pgtype.FlatArray[pgtype.Int4]{{Int32: 1, Valid: true}, {}}))
+}
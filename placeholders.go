@@ -0,0 +1,84 @@
+package queryf
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// contextLen is the maximum number of bytes of Placeholder.Context on each side
+// of the placeholder.
+const contextLen = 20
+
+// Placeholder is a placeholder found in a query by Placeholders.
+type Placeholder struct {
+	// Text is the placeholder as written in the query, e.g. $1 or :name.
+	Text string
+	// Index is the 1-based argument position. Named placeholders have index 0.
+	Index int
+	// Name is the name of named placeholders.
+	Name string
+	// Offset is the byte offset of the placeholder in the query.
+	Offset int
+	// Context is the text of the line around the placeholder, e.g.
+	// "WHERE id = $1 AND".
+	Context string
+}
+
+// Placeholders returns the placeholders of the query in order, skipping string
+// literals, quoted identifiers, comments and dollar-quoted bodies. It returns
+// ErrUnterminated with the placeholders found before an unclosed literal or
+// comment.
+//
+// Example:
+//
+//	ps, _ := queryf.Placeholders("SELECT * FROM users WHERE id = $1")
+//	fmt.Println(ps[0].Index, ps[0].Offset)
+//	// Output: 1 31
+func Placeholders(query string) ([]Placeholder, error) {
+	return Default().Placeholders(query)
+}
+
+// Placeholders returns the placeholders of the query in the Formatter
+// placeholder style. See the package level Placeholders for details.
+func (f *Formatter) Placeholders(query string) ([]Placeholder, error) {
+	var placeholders []Placeholder
+	t := newTokenizer(query, f.syntax())
+	for tok, ok := t.next(); ok; tok, ok = t.next() {
+		if tok.unterminated {
+			return placeholders, fmt.Errorf("%w at offset %d", ErrUnterminated, tok.start)
+		}
+		if tok.kind != tokenPlaceholder {
+			continue
+		}
+		p := tok.placeholder
+		placeholders = append(placeholders, Placeholder{
+			Text:    query[p.start:p.end],
+			Index:   p.index,
+			Name:    p.name,
+			Offset:  p.start,
+			Context: placeholderContext(query, p.start, p.end),
+		})
+	}
+	return placeholders, nil
+}
+
+// placeholderContext returns up to contextLen bytes of the line on each side of
+// query[start:end], without splitting UTF-8 characters.
+func placeholderContext(query string, start, end int) string {
+	from := max(start-contextLen, 0)
+	if i := strings.LastIndexByte(query[from:start], '\n'); i != -1 {
+		from += i + 1
+	}
+	for from < start && !utf8.RuneStart(query[from]) {
+		from++
+	}
+	to := min(end+contextLen, len(query))
+	if i := strings.IndexByte(query[end:to], '\n'); i != -1 {
+		to = end + i
+	}
+	for to < len(query) && to > end && !utf8.RuneStart(query[to]) {
+		to--
+	}
+	return strings.TrimSpace(query[from:to])
+}
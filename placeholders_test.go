@@ -0,0 +1,37 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestPlaceholders() {
+	ps, err := Placeholders("SELECT * FROM users\nWHERE id = $1 AND name = '$2' -- $3\n  OR name = $2")
+	suite.Nil(err)
+	suite.Equal([]Placeholder{
+		{Text: "$1", Index: 1, Offset: 31, Context: "WHERE id = $1 AND name = '$2' --"},
+		{Text: "$2", Index: 2, Offset: 68, Context: "OR name = $2"},
+	}, ps)
+
+	ps, err = New(WithPlaceholderStyle(Named)).Placeholders(`SELECT :id::int, @name`)
+	suite.Nil(err)
+	suite.Equal([]Placeholder{
+		{Text: ":id", Name: "id", Offset: 7, Context: "SELECT :id::int, @name"},
+		{Text: "@name", Name: "name", Offset: 17, Context: "SELECT :id::int, @name"},
+	}, ps)
+
+	ps, err = New(WithPlaceholderStyle(Question)).Placeholders(`SELECT ?, ?`)
+	suite.Nil(err)
+	suite.Equal([]int{1, 2}, []int{ps[0].Index, ps[1].Index})
+
+	ps, err = Placeholders(`SELECT ñññññññññññ$1ñññññññññññ`)
+	suite.Nil(err)
+	suite.Equal(`ññññññññññ$1ññññññññññ`, ps[0].Context)
+
+	ps, err = Placeholders(`SELECT $1, 'unterminated $2`)
+	suite.ErrorIs(err, ErrUnterminated)
+	suite.EqualError(err, "queryf: unterminated literal or comment at offset 11")
+	suite.Len(ps, 1)
+
+	for _, query := range []string{`SELECT "a`, `SELECT /* a`, `SELECT $$ a`, `SELECT E'a\'`} {
+		_, err = Placeholders(query)
+		suite.ErrorIs(err, ErrUnterminated, query)
+	}
+	_, err = Placeholders(`SELECT 1 -- comment`)
+	suite.Nil(err)
+}
@@ -0,0 +1,183 @@
+package queryf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// pgconnPkgPath is the package of the pgx v5 errors, which are detected
+// without importing it.
+const pgconnPkgPath = "github.com/jackc/pgx/v5/pgconn"
+
+// FormatWithError returns the message of the error returned by running the
+// query, followed by the query formatted and a caret under the position the
+// error points to, which Postgres reports on the query before formatting. The
+// detail and the hint of the error follow the query. Both *pq.Error and
+// *pgconn.PgError errors are recognized, anywhere in the chain of err, and
+// other errors are followed by the formatted query only.
+//
+// Example:
+//
+//	query := "SELECT nme FROM users WHERE id = $1"
+//	_, err := db.Exec(query, 1)
+//	fmt.Println(queryf.FormatWithError(query, []any{1}, err))
+//	// Output:
+//	// ERROR: column "nme" does not exist (SQLSTATE 42703)
+//	// SELECT nme FROM users WHERE id = 1
+//	//        ^
+func FormatWithError(query string, args []any, err error) string {
+	return Default().FormatWithError(query, args, err)
+}
+
+// FormatWithError returns the error and the query formatted with the Formatter
+// configuration. See the package level FormatWithError for details.
+func (f *Formatter) FormatWithError(query string, args []any, err error) string {
+	query, args = rewriteQuery(query, args)
+	formatted, replacements := f.formatTracked(query, args)
+	if err == nil {
+		return formatted
+	}
+	e, ok := asServerError(err)
+	if !ok {
+		return err.Error() + "\n" + formatted
+	}
+	var b strings.Builder
+	if e.severity == "" {
+		e.severity = "ERROR"
+	}
+	fmt.Fprintf(&b, "%s: %s (SQLSTATE %s)\n", e.severity, e.message, e.code)
+	if e.position > 0 {
+		formatted = MarkPosition(formatted, mapOffset(charOffset(query, e.position), replacements))
+	}
+	b.WriteString(formatted)
+	if e.detail != "" {
+		b.WriteString("\nDETAIL: " + e.detail)
+	}
+	if e.hint != "" {
+		b.WriteString("\nHINT: " + e.hint)
+	}
+	return b.String()
+}
+
+// serverError holds the fields of a Postgres error shared by the drivers.
+type serverError struct {
+	severity, code, message, detail, hint string
+	// position is the 1-based character position in the query, or 0.
+	position int
+}
+
+// asServerError returns the first *pq.Error or *pgconn.PgError in the chain of
+// err.
+func asServerError(err error) (serverError, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		position, _ := strconv.Atoi(pqErr.Position)
+		return serverError{pqErr.Severity, string(pqErr.Code), pqErr.Message, pqErr.Detail, pqErr.Hint, position}, true
+	}
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.ValueOf(err)
+		if v.Kind() != reflect.Ptr || v.IsNil() || !isNamedType(v.Type().Elem(), pgconnPkgPath, "PgError") {
+			continue
+		}
+		v = v.Elem()
+		return serverError{
+			severity: v.FieldByName("Severity").String(),
+			code:     v.FieldByName("Code").String(),
+			message:  v.FieldByName("Message").String(),
+			detail:   v.FieldByName("Detail").String(),
+			hint:     v.FieldByName("Hint").String(),
+			position: int(v.FieldByName("Position").Int()),
+		}, true
+	}
+	return serverError{}, false
+}
+
+// replacement records where a placeholder was replaced by its literal.
+type replacement struct {
+	// start and end are the byte offsets of the placeholder in the query.
+	start, end int
+	// length is the length of the literal.
+	length int
+}
+
+// formatTracked formats the query without colors, which would shift the
+// offsets, and returns the replacements made, in order.
+func (f *Formatter) formatTracked(query string, args []any) (string, []replacement) {
+	plain := *f
+	plain.cache = nil
+	plain.color, plain.keywordColor = false, false
+	var replacements []replacement
+	formatted := substitute(query, plain.syntax(), func(p placeholder) (string, bool) {
+		i := p.index - 1
+		if i < 0 || i >= len(args) {
+			return "", false
+		}
+		literal := plain.formatArg(p.index, args[i])
+		replacements = append(replacements, replacement{start: p.start, end: p.end, length: len(literal)})
+		return literal, true
+	})
+	return formatted, replacements
+}
+
+// mapOffset returns the offset in the formatted query of the byte offset in
+// the query. Offsets inside a placeholder map to the start of its literal.
+func mapOffset(offset int, replacements []replacement) int {
+	shift := 0
+	for _, r := range replacements {
+		if offset < r.start {
+			break
+		}
+		if offset < r.end {
+			return r.start + shift
+		}
+		shift += r.length - (r.end - r.start)
+	}
+	return offset + shift
+}
+
+// charOffset returns the byte offset of the 1-based character position in s,
+// which is how Postgres reports error positions.
+func charOffset(s string, position int) int {
+	n := 1
+	for i := range s {
+		if n == position {
+			return i
+		}
+		n++
+	}
+	return len(s)
+}
+
+// MarkPosition returns the formatted query with a caret under the byte offset,
+// on a line inserted after the line of the offset, to point at the position of
+// an error. Tabs are kept in the indentation of the caret so it stays aligned.
+//
+// Example:
+//
+//	fmt.Println(queryf.MarkPosition("SELECT nme FROM users", 7))
+//	// Output:
+//	// SELECT nme FROM users
+//	//        ^
+func MarkPosition(query string, offset int) string {
+	offset = min(max(offset, 0), len(query))
+	lineStart := strings.LastIndexByte(query[:offset], '\n') + 1
+	lineEnd := len(query)
+	if n := strings.IndexByte(query[offset:], '\n'); n != -1 {
+		lineEnd = offset + n
+	}
+	var caret strings.Builder
+	for _, r := range query[lineStart:offset] {
+		if r == '\t' {
+			caret.WriteByte('\t')
+		} else {
+			caret.WriteByte(' ')
+		}
+	}
+	caret.WriteByte('^')
+	return query[:lineEnd] + "\n" + caret.String() + query[lineEnd:]
+}
@@ -0,0 +1,32 @@
+package queryf
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (suite *QueryfTestSuite) TestMarkPosition() {
+	suite.Equal("SELECT nme FROM users\n       ^", MarkPosition("SELECT nme FROM users", 7))
+	suite.Equal("SELECT *\n\tFROM usrs\n\t     ^\nLIMIT 1", MarkPosition("SELECT *\n\tFROM usrs\nLIMIT 1", 15))
+	suite.Equal("SELECT\n^", MarkPosition("SELECT", -1))
+	suite.Equal("SELECT\n      ^", MarkPosition("SELECT", 100))
+}
+
+func (suite *QueryfTestSuite) TestFormatWithError() {
+	query := `SELECT * FROM users WHERE name = $1 AND ide = $2`
+	err := &pgconn.PgError{Severity: "ERROR", Code: "42703", Message: `column "ide" does not exist`, Position: 41}
+	expected := `ERROR: column "ide" does not exist (SQLSTATE 42703)
+SELECT * FROM users WHERE name = 'O''Brien' AND ide = 1
+                                                ^`
+	suite.Equal(expected, FormatWithError(query, []any{"O'Brien", 1}, err))
+	suite.Equal(expected, FormatWithError(query, []any{"O'Brien", 1}, fmt.Errorf("find user: %w", err)))
+
+	// Positions count characters, not bytes
+	err = &pgconn.PgError{Code: "42601", Message: `syntax error at or near "FORM"`, Position: 19}
+	suite.Equal(`ERROR: syntax error at or near "FORM" (SQLSTATE 42601)
+SELECT 'ação', 1 FORM users
+                 ^`, FormatWithError(`SELECT 'ação', $1 FORM users`, []any{1}, err))
+
+	suite.Equal("ERROR: boom (SQLSTATE XX000)\nSELECT 1", FormatWithError(`SELECT $1`, []any{1}, &pgconn.PgError{Code: "XX000", Message: "boom"}))
+}
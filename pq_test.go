@@ -0,0 +1,15 @@
+package queryf
+
+import "github.com/lib/pq"
+
+func (suite *QueryfTestSuite) TestPqArray() {
+	suite.Equal(`SELECT '{"a","b,c"}', '{1,2}', '{1.5}', '{t}'`, Format(`SELECT $1, $2, $3, $4`,
+		pq.Array([]string{"a", "b,c"}), pq.Array([]int64{1, 2}), pq.Array([]float64{1.5}), pq.Array([]bool{true})))
+
+	// Slices without a concrete pq type are wrapped by pq.GenericArray
+	suite.Equal(`SELECT '{{1},{2}}', '{"x"}'`, Format(`SELECT $1, $2`, pq.Array([][]int{{1}, {2}}), pq.Array([]any{"x"})))
+	suite.Equal(GenericArray, NewArgument(pq.Array([]int{1})).GetType())
+
+	var null []string
+	suite.Equal(`SELECT NULL, NULL`, Format(`SELECT $1, $2`, pq.Array(null), pq.Array([]int(nil))))
+}
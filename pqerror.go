@@ -0,0 +1,24 @@
+package queryf
+
+// FormatPqError returns the message of the error returned by running the query
+// with lib/pq, followed by the query formatted and a caret under the position
+// the error points to. It is FormatWithError with the arguments in the order of
+// db.Exec.
+//
+// Example:
+//
+//	_, err := db.Exec("SELECT nme FROM users WHERE id = $1", 1)
+//	fmt.Println(queryf.FormatPqError(err, "SELECT nme FROM users WHERE id = $1", 1))
+//	// Output:
+//	// ERROR: column "nme" does not exist (SQLSTATE 42703)
+//	// SELECT nme FROM users WHERE id = 1
+//	//        ^
+func FormatPqError(err error, query string, args ...any) string {
+	return Default().FormatPqError(err, query, args...)
+}
+
+// FormatPqError returns the error and the query formatted with the Formatter
+// configuration. See the package level FormatPqError for details.
+func (f *Formatter) FormatPqError(err error, query string, args ...any) string {
+	return f.FormatWithError(query, args, err)
+}
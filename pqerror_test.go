@@ -0,0 +1,37 @@
+package queryf
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+func (suite *QueryfTestSuite) TestFormatPqError() {
+	query := `SELECT nme FROM users WHERE name = $1 AND id = $2`
+	err := &pq.Error{Severity: "ERROR", Code: "42703", Message: `column "nme" does not exist`, Position: "8"}
+	suite.Equal(`ERROR: column "nme" does not exist (SQLSTATE 42703)
+SELECT nme FROM users WHERE name = 'John' AND id = 1
+       ^`, FormatPqError(err, query, "John", 1))
+
+	// Positions after the placeholders are shifted by the length of the literals
+	query = "SELECT *\nFROM users\nWHERE name = $1 AND ide = $2\nLIMIT 1"
+	err = &pq.Error{Code: "42703", Message: `column "ide" does not exist`, Position: "41", Hint: `Perhaps you meant to reference the column "users.id".`}
+	suite.Equal(`ERROR: column "ide" does not exist (SQLSTATE 42703)
+SELECT *
+FROM users
+WHERE name = 'John' AND ide = 1
+                        ^
+LIMIT 1
+HINT: Perhaps you meant to reference the column "users.id".`, FormatPqError(fmt.Errorf("find user: %w", err), query, "John", 1))
+
+	// Positions inside a placeholder point to the start of its literal
+	err = &pq.Error{Code: "22P02", Message: `invalid input syntax for type integer: "x"`, Position: "33", Detail: "detail"}
+	suite.Equal(`ERROR: invalid input syntax for type integer: "x" (SQLSTATE 22P02)
+SELECT * FROM users WHERE id = 'x'
+                               ^
+DETAIL: detail`, FormatPqError(err, `SELECT * FROM users WHERE id = $1`, "x"))
+
+	suite.Equal("connection refused\nSELECT 1", FormatPqError(errors.New("connection refused"), `SELECT $1`, 1))
+	suite.Equal("SELECT 1", FormatPqError(nil, `SELECT $1`, 1))
+}
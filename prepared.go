@@ -0,0 +1,42 @@
+package queryf
+
+import "strings"
+
+// FormatPrepared returns a psql script that prepares the query as the
+// statement name and executes it with the arguments as literals. The server
+// plans prepared statements differently from the queries Format returns, so
+// the script reproduces what the driver actually runs more faithfully. The
+// placeholders are rebound to $N, as PREPARE expects.
+//
+// Example:
+//
+//	fmt.Println(queryf.FormatPrepared("find_user", "SELECT * FROM users WHERE id = $1 AND name = $2", 1, "John"))
+//	// Output:
+//	// PREPARE find_user AS SELECT * FROM users WHERE id = $1 AND name = $2;
+//	// EXECUTE find_user(1, 'John');
+func FormatPrepared(name, query string, args ...any) string {
+	return Default().FormatPrepared(name, query, args...)
+}
+
+// FormatPrepared returns a PREPARE and EXECUTE script with the arguments
+// formatted with the Formatter configuration. See the package level
+// FormatPrepared for details.
+func (f *Formatter) FormatPrepared(name, query string, args ...any) string {
+	if f.style() != Dollar {
+		query = Rebind(query, f.style(), Dollar)
+	}
+	var b strings.Builder
+	b.WriteString("PREPARE ")
+	b.WriteString(name)
+	b.WriteString(" AS ")
+	b.WriteString(strings.TrimRight(strings.TrimSpace(query), ";"))
+	b.WriteString(";\nEXECUTE ")
+	b.WriteString(name)
+	if len(args) > 0 {
+		b.WriteByte('(')
+		b.WriteString(strings.Join(f.FormatArgs(args...), ", "))
+		b.WriteByte(')')
+	}
+	b.WriteByte(';')
+	return b.String()
+}
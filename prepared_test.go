@@ -0,0 +1,13 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestFormatPrepared() {
+	suite.Equal("PREPARE find_user AS SELECT * FROM users WHERE id = $1 AND name = $2;\n"+
+		"EXECUTE find_user(1, 'John');",
+		FormatPrepared("find_user", `SELECT * FROM users WHERE id = $1 AND name = $2`, 1, "John"))
+	suite.Equal("PREPARE now AS SELECT now();\nEXECUTE now;", FormatPrepared("now", "SELECT now();\n"))
+
+	f := New(WithPlaceholderStyle(Question), WithNullLiteral("null"))
+	suite.Equal("PREPARE q AS SELECT * FROM users WHERE id = $1 AND deleted_at = $2;\n"+
+		"EXECUTE q(1, null);",
+		f.FormatPrepared("q", `SELECT * FROM users WHERE id = ? AND deleted_at = ?`, 1, nil))
+}
@@ -0,0 +1,278 @@
+package queryf
+
+import "strings"
+
+// KeywordCase is the casing of the SQL keywords written by Pretty.
+type KeywordCase string
+
+const (
+	// Preserve keeps keywords as written in the query. It is the default.
+	Preserve KeywordCase = "preserve"
+	// Upper writes keywords in upper case.
+	Upper KeywordCase = "upper"
+	// Lower writes keywords in lower case.
+	Lower KeywordCase = "lower"
+)
+
+// prettyIndent is the indentation of each level of the pretty-printed query.
+const prettyIndent = "  "
+
+// WithKeywordCase sets the casing of the SQL keywords written by Pretty.
+// Defaults to Preserve.
+func WithKeywordCase(c KeywordCase) Option {
+	return func(f *Formatter) {
+		f.keywordCase = c
+	}
+}
+
+// clauses are the keyword sequences that start a line in Pretty, longest
+// first so LEFT OUTER JOIN is matched before JOIN.
+var clauses = [][]string{
+	{"left", "outer", "join"}, {"right", "outer", "join"}, {"full", "outer", "join"},
+	{"group", "by"}, {"order", "by"}, {"union", "all"}, {"insert", "into"}, {"delete", "from"},
+	{"on", "conflict"}, {"left", "join"}, {"right", "join"}, {"full", "join"}, {"inner", "join"},
+	{"cross", "join"}, {"select"}, {"from"}, {"where"}, {"having"}, {"limit"}, {"offset"},
+	{"union"}, {"intersect"}, {"except"}, {"values"}, {"set"}, {"returning"}, {"update"},
+	{"with"}, {"join"},
+}
+
+// keywords are the words whose case is changed by WithKeywordCase.
+var keywords = map[string]bool{
+	"all": true, "and": true, "any": true, "as": true, "asc": true, "between": true, "by": true,
+	"case": true, "conflict": true, "cross": true, "delete": true, "desc": true, "distinct": true,
+	"do": true, "else": true, "end": true, "except": true, "exists": true, "false": true,
+	"for": true, "from": true, "full": true, "group": true, "having": true, "ilike": true,
+	"in": true, "inner": true, "insert": true, "intersect": true, "into": true, "is": true,
+	"join": true, "left": true, "like": true, "limit": true, "not": true, "nothing": true,
+	"null": true, "offset": true, "on": true, "or": true, "order": true, "outer": true,
+	"returning": true, "right": true, "select": true, "set": true, "then": true, "true": true,
+	"union": true, "update": true, "using": true, "values": true, "when": true, "where": true,
+	"with": true,
+}
+
+// Pretty formats the query like Format and then lays it out for reading: each
+// clause starts a line, AND and OR conditions are indented under it, and
+// subqueries are indented one more level.
+//
+// Example:
+//
+//	fmt.Println(queryf.Pretty("SELECT id, name FROM users WHERE active = $1 AND age > $2 ORDER BY name", true, 18))
+//	// Output:
+//	// SELECT id, name
+//	// FROM users
+//	// WHERE active = true
+//	//   AND age > 18
+//	// ORDER BY name
+func Pretty(query string, args ...any) string {
+	return Default().Pretty(query, args...)
+}
+
+// Pretty formats the query with the Formatter configuration and lays it out
+// for reading. See the package level Pretty for details.
+func (f *Formatter) Pretty(query string, args ...any) string {
+	// Keywords are highlighted by the pretty printer, after the layout.
+	plain := *f
+	plain.cache = nil
+	plain.keywordColor = false
+	return f.prettify(plain.Format(query, args...))
+}
+
+type lexemeKind int
+
+const (
+	lexemeWord lexemeKind = iota
+	lexemePunct
+	lexemeLiteral
+	lexemeLineComment
+)
+
+// lexeme is a word, punctuation, literal or comment of the query.
+type lexeme struct {
+	text string
+	kind lexemeKind
+	// space reports whether the lexeme was preceded by whitespace.
+	space bool
+}
+
+// lexemes splits the query into lexemes, dropping whitespace. backslash is set
+// for dialects whose string literals have backslash escapes.
+func lexemes(query string, backslash bool) []lexeme {
+	var ls []lexeme
+	space := false
+	t := newTokenizer(query, syntax{style: Named, backslash: backslash})
+	for tok, ok := t.next(); ok; tok, ok = t.next() {
+		text := query[tok.start:tok.end]
+		switch {
+		case tok.kind == tokenComment && strings.HasPrefix(text, "--"):
+			ls = append(ls, lexeme{text: text, kind: lexemeLineComment, space: space})
+		case tok.kind == tokenText:
+			for i := 0; i < len(text); {
+				c := text[i]
+				end := i + 1
+				kind := lexemePunct
+				switch {
+				case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+					space = true
+					i++
+					continue
+				case c == '\x1b':
+					// ANSI color codes written by WithColor.
+					if m := strings.IndexByte(text[i:], 'm'); m != -1 {
+						end = i + m + 1
+					}
+					kind = lexemeLiteral
+				case isNameChar(c) || c >= 0x80:
+					for end < len(text) && (isNameChar(text[end]) || text[end] == '$' || text[end] >= 0x80) {
+						end++
+					}
+					kind = lexemeWord
+				case c == ':' && end < len(text) && text[end] == ':':
+					end++
+				case strings.IndexByte("<>=!|&+-*/%~^#", c) != -1:
+					for end < len(text) && strings.IndexByte("<>=!|&+-*/%~^#", text[end]) != -1 {
+						end++
+					}
+				}
+				ls = append(ls, lexeme{text: text[i:end], kind: kind, space: space})
+				space = false
+				i = end
+			}
+			continue
+		default:
+			ls = append(ls, lexeme{text: text, kind: lexemeLiteral, space: space})
+		}
+		space = false
+	}
+	return ls
+}
+
+// prettyPrinter lays out the lexemes of a query.
+type prettyPrinter struct {
+	strings.Builder
+	keywordCase  KeywordCase
+	keywordColor bool
+	// subqueries is the stack of open parentheses, true for those that open a
+	// subquery.
+	subqueries []bool
+	depth      int
+	between    bool
+	comma      bool
+	// newline is set when the next lexeme starts a line at level.
+	newline bool
+	level   int
+}
+
+func (f *Formatter) prettify(query string) string {
+	return f.layout(lexemes(query, f.syntax().backslash))
+}
+
+// layout lays out the lexemes of a query, see Pretty.
+func (f *Formatter) layout(ls []lexeme) string {
+	if f.quoteIdentifiers {
+		ls = f.quoteIdentifierLexemes(ls)
+	}
+	p := &prettyPrinter{keywordCase: f.keywordCase, keywordColor: f.keywordColor}
+	for i := 0; i < len(ls); i++ {
+		l := ls[i]
+		lower := strings.ToLower(l.text)
+		inline := len(p.subqueries) > 0 && !p.subqueries[len(p.subqueries)-1]
+		switch {
+		case l.kind == lexemeWord && !inline && p.clauseAt(ls, i) > 0:
+			n := p.clauseAt(ls, i)
+			p.breakLine(p.depth)
+			for j := i; j < i+n; j++ {
+				p.write(ls[j], j > i)
+			}
+			i += n - 1
+			continue
+		case l.kind == lexemeWord && (lower == "and" || lower == "or") && !inline && !p.between:
+			p.breakLine(p.depth + 1)
+		case l.text == "(":
+			subquery := i+1 < len(ls) && (strings.EqualFold(ls[i+1].text, "select") || strings.EqualFold(ls[i+1].text, "with"))
+			p.write(l, l.space)
+			p.subqueries = append(p.subqueries, subquery)
+			if subquery {
+				p.depth++
+				p.breakLine(p.depth)
+			}
+			continue
+		case l.text == ")" && len(p.subqueries) > 0:
+			if p.subqueries[len(p.subqueries)-1] {
+				p.depth--
+				p.breakLine(p.depth)
+			}
+			p.subqueries = p.subqueries[:len(p.subqueries)-1]
+		}
+		if lower == "between" {
+			p.between = true
+		} else if lower == "and" {
+			p.between = false
+		}
+		p.write(l, l.space)
+		if l.kind == lexemeLineComment {
+			p.breakLine(p.depth)
+		}
+	}
+	return p.String()
+}
+
+// clauseAt returns the number of lexemes of the clause starting at i, or 0 if
+// no clause starts there.
+func (p *prettyPrinter) clauseAt(ls []lexeme, i int) int {
+	if i > 0 && ls[i-1].kind == lexemeWord {
+		// UPDATE in DO UPDATE and FOR UPDATE doesn't start a statement.
+		if prev := strings.ToLower(ls[i-1].text); prev == "do" || prev == "for" {
+			return 0
+		}
+	}
+	for _, clause := range clauses {
+		if i+len(clause) > len(ls) {
+			continue
+		}
+		matched := true
+		for j, word := range clause {
+			if ls[i+j].kind != lexemeWord || !strings.EqualFold(ls[i+j].text, word) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return len(clause)
+		}
+	}
+	return 0
+}
+
+// breakLine starts a new line at the indentation level before the next
+// lexeme. Consecutive breaks only start one line, at the last level.
+func (p *prettyPrinter) breakLine(level int) {
+	if p.Len() > 0 {
+		p.newline = true
+		p.level = level
+	}
+}
+
+// write writes the lexeme, after a space if space is set.
+func (p *prettyPrinter) write(l lexeme, space bool) {
+	text := l.text
+	if l.kind == lexemeWord && keywords[strings.ToLower(text)] {
+		switch p.keywordCase {
+		case Upper:
+			text = strings.ToUpper(text)
+		case Lower:
+			text = strings.ToLower(text)
+		}
+		if p.keywordColor {
+			text = colorize(text, colorKeyword)
+		}
+	}
+	if p.newline {
+		p.WriteByte('\n')
+		p.WriteString(strings.Repeat(prettyIndent, p.level))
+	} else if p.Len() > 0 && (space || p.comma) && l.text != "," && l.text != ")" && !strings.HasSuffix(p.String(), "(") {
+		p.WriteByte(' ')
+	}
+	p.newline = false
+	p.comma = l.text == ","
+	p.WriteString(text)
+}
@@ -0,0 +1,53 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestPretty() {
+	suite.Equal("SELECT id, name\nFROM users\nWHERE active = true\n  AND age > 18\nORDER BY name",
+		Pretty(`SELECT id, name FROM users WHERE active = $1 AND age > $2 ORDER BY name`, true, 18))
+
+	suite.Equal(`select u.id, count(*)
+from users u
+left join orders o on o.user_id=u.id
+where u.id in (
+  select user_id
+  from bans
+  where reason = 'x  where'
+    and created_at between 'a' and 'b'
+)
+  or u.age>=-1
+group by u.id
+having count(*) > 1
+limit 10 -- note
+offset 5`, Pretty(`select u.id,count(*) from users u left join orders o on o.user_id=u.id where u.id in (select user_id
+	from bans where reason = 'x  where' and created_at between $1 and $2) or u.age>=-1 group by u.id having count(*) > 1 limit 10 -- note
+	offset 5`, "a", "b"))
+
+	suite.Equal(`SELECT extract(year FROM now())`, Pretty(`SELECT extract(year FROM now())`))
+	suite.Equal(``, Pretty(``))
+}
+
+func (suite *QueryfTestSuite) TestPrettyKeywordCase() {
+	query := `insert into t (a, b) values ($1, 'from') on conflict (a) do update set b = excluded.b returning a::text`
+	suite.Equal("INSERT INTO t (a, b)\nVALUES (1, 'from')\nON CONFLICT (a) DO UPDATE\nSET b = excluded.b\nRETURNING a::text",
+		New(WithKeywordCase(Upper)).Pretty(query, 1))
+	suite.Equal("insert into t (a, b)\nvalues (1, 'from')", New(WithKeywordCase(Lower)).Pretty(`INSERT INTO t (a, b) VALUES ($1, 'from')`, 1))
+	suite.Equal("Select 1\nFrom t", New(WithKeywordCase(Preserve)).Pretty(`Select 1 From t`))
+}
+
+func (suite *QueryfTestSuite) TestPrettyQuoteIdentifiers() {
+	query := `select u.ID, count(*) as total from Users u where u.created_at > date '2022-02-10' and u.name = $1 ` +
+		`and u.age::int > 1 and extract(year from now()) > 2000 group by u.ID order by total desc nulls last`
+	suite.Equal(`SELECT "u"."id", count(*) AS "total"
+FROM "users" "u"
+WHERE "u"."created_at" > date '2022-02-10'
+  AND "u"."name" = 'John'
+  AND "u"."age"::int > 1
+  AND extract(year FROM now()) > 2000
+GROUP BY "u"."id"
+ORDER BY "total" DESC nulls last`, New(WithQuoteIdentifiers(), WithKeywordCase(Upper)).Pretty(query, "John"))
+
+	suite.Equal(`SELECT "ID"
+FROM "USERS"`, New(WithQuoteIdentifiers(), WithDialect(Oracle)).Pretty(`SELECT id FROM users`))
+	suite.Equal("SELECT `Id`\nFROM `users`", New(WithQuoteIdentifiers(), WithDialect(MySQL)).Pretty(`SELECT Id FROM users`))
+	suite.Equal("SELECT [id]\nFROM [users]\nWHERE [name] = :name", New(WithQuoteIdentifiers(), WithDialect(SQLServer)).Pretty(`SELECT id FROM users WHERE name = :name`))
+	suite.Equal("SELECT \"already quoted\"\nFROM \"t\"", New(WithQuoteIdentifiers()).Pretty(`SELECT "already quoted" FROM t`))
+}
@@ -0,0 +1,52 @@
+package queryf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// psqlEscaper escapes the quoted arguments of psql meta-commands.
+var psqlEscaper = strings.NewReplacer(`'`, `''`, `\`, `\\`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// FormatPsql returns a psql script that sets a variable per argument with
+// \set and runs the query with the placeholders replaced by references to
+// them, so the query can be replayed in psql with its parameters still apart
+// from the SQL. Strings are referenced as :'pN', which psql quotes, and other
+// literals as :pN.
+//
+// Example:
+//
+//	fmt.Println(queryf.FormatPsql("SELECT * FROM users WHERE id = $1 AND name = $2", 1, "John"))
+//	// Output:
+//	// \set p1 '1'
+//	// \set p2 'John'
+//	// SELECT * FROM users WHERE id = :p1 AND name = :'p2';
+func FormatPsql(query string, args ...any) string {
+	return Default().FormatPsql(query, args...)
+}
+
+// FormatPsql returns a psql script with the arguments formatted with the
+// Formatter configuration. See the package level FormatPsql for details.
+func (f *Formatter) FormatPsql(query string, args ...any) string {
+	var b strings.Builder
+	refs := make([]string, len(args))
+	for i, arg := range args {
+		name := fmt.Sprintf("p%d", i+1)
+		value := f.literal(i+1, arg)
+		refs[i] = ":" + name
+		if v, ok := unquote(value); ok {
+			value = v
+			refs[i] = ":'" + name + "'"
+		}
+		fmt.Fprintf(&b, "\\set %s '%s'\n", name, psqlEscaper.Replace(value))
+	}
+	query = substitute(query, f.syntax(), func(p placeholder) (string, bool) {
+		if p.index < 1 || p.index > len(args) {
+			return "", false
+		}
+		return refs[p.index-1], true
+	})
+	b.WriteString(strings.TrimRight(strings.TrimSpace(query), ";"))
+	b.WriteByte(';')
+	return b.String()
+}
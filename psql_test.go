@@ -0,0 +1,23 @@
+package queryf
+
+import "math"
+
+func (suite *QueryfTestSuite) TestFormatPsql() {
+	suite.Equal("\\set p1 '1'\n"+
+		"\\set p2 'John'\n"+
+		"SELECT * FROM users WHERE id = :p1 AND name = :'p2';",
+		FormatPsql(`SELECT * FROM users WHERE id = $1 AND name = $2`, 1, "John"))
+	suite.Equal("\\set p1 'it''s a\\\\b\\nc'\n"+
+		"\\set p2 'NULL'\n"+
+		"\\set p3 '{\"a\",\"b\"}'\n"+
+		"\\set p4 '''NaN''::float8'\n"+
+		"SELECT :'p1', :p2, :'p3', :p4, :'p1', $5;",
+		FormatPsql("SELECT $1, $2, $3, $4, $1, $5;\n", "it's a\\b\nc", nil, []string{"a", "b"}, math.NaN()))
+	suite.Equal("SELECT 1;", FormatPsql(`SELECT 1`))
+
+	f := New(WithPlaceholderStyle(Question), WithRedactedParams(2))
+	suite.Equal("\\set p1 'true'\n"+
+		"\\set p2 '[REDACTED]'\n"+
+		"UPDATE users SET active = :p1, password = :'p2';",
+		f.FormatPsql(`UPDATE users SET active = ?, password = ?`, true, "secret"))
+}
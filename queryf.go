@@ -1,9 +1,10 @@
 package queryf
 
 import (
+	"database/sql/driver"
+	"encoding/hex"
 	"fmt"
 	"reflect"
-	"regexp"
 	"strings"
 	"time"
 
@@ -19,13 +20,32 @@ const (
 	Pointer      ParameterType = "pointer"
 	Null         ParameterType = "null"
 	Time         ParameterType = "time"
+	Date         ParameterType = "date"
+	TimeOfDay    ParameterType = "time_of_day"
+	Bytes        ParameterType = "bytes"
+	UUID         ParameterType = "uuid"
 	Slice        ParameterType = "slice"
 	GenericArray ParameterType = "generic_array"
+	Map          ParameterType = "map"
+	Struct       ParameterType = "struct"
+	Valuer       ParameterType = "valuer"
+	Numeric      ParameterType = "numeric"
+	Money        ParameterType = "money"
+	TableValued  ParameterType = "table_valued"
+	Network      ParameterType = "network"
+	JSON         ParameterType = "json"
+	Hstore       ParameterType = "hstore"
+	Composite    ParameterType = "composite"
+	RangeType    ParameterType = "range"
+	Float        ParameterType = "float"
+	Unsupported  ParameterType = "unsupported"
 )
 
 // Format will return the query with the arguments formatted.
 // This will replace the $1, $2, etc. with the arguments given, similar to what the
-// database/sql package does, but for debugging purposes.
+// database/sql package does, but for debugging purposes. When the first argument
+// is a pgx.QueryRewriter, such as pgx.NamedArgs, the query is rewritten first,
+// as pgx does before sending it.
 //
 //	** This is not meant to be used in production code. **
 //	** Passing this resulting string to a database may lead to SQL injections. **
@@ -37,72 +57,146 @@ const (
 //	fmt.Println(Format(query, args...))
 //	// Output: SELECT * FROM users WHERE id = 1 AND name = 'John'
 func Format(query string, args ...any) string {
-	queryb := []byte(query)
-	for i, arg := range args {
-		index := i + 1
-		re := regexp.MustCompile(fmt.Sprintf(`\$%d\b`, index))
-		queryb = re.ReplaceAll(queryb, []byte(NewArgument(arg).format()))
-	}
-	return string(queryb)
+	return Default().Format(query, args...)
+}
+
+// FormatArgs returns the SQL literal of each argument, as Format would write
+// them, for logging the query and its arguments as separate fields.
+//
+// Example:
+//
+//	fmt.Println(FormatArgs(1, "John", nil))
+//	// Output: [1 'John' NULL]
+func FormatArgs(args ...any) []string {
+	return Default().FormatArgs(args...)
 }
 
+// NewArgument returns an Argument formatted with the default configuration.
 func NewArgument(arg any) *Argument {
-	return &Argument{arg: arg}
+	return Default().NewArgument(arg)
 }
 
+// Argument is a value bound to a query, formatted as a SQL literal by Format.
+// It implements fmt.Stringer and json.Marshaler, so it can be used directly in
+// structured logs.
 type Argument struct {
-	arg    any
-	rValue *reflect.Value
-	rType  *reflect.Type
+	arg       any
+	formatter *Formatter
+	// rValue and rType cache the reflection of arg once reflected is set.
+	// They are stored by value, so caching them doesn't allocate.
+	rValue    reflect.Value
+	rType     reflect.Type
+	reflected bool
+	// ancestors are the pointers, maps and slices a nested argument belongs
+	// to, e.g. the slice of an element, and depth the number of parents.
+	ancestors *ancestor
+	depth     int
+	// index is the 1-based position of the argument passed to the redactors,
+	// or 0 for named and standalone arguments. inner is set for the values
+	// nested in or wrapped by another argument, redacted along with it.
+	index int
+	inner bool
 }
 
-func (a *Argument) getReflectedValue() reflect.Value {
-	if a.rValue == nil {
-		v := reflect.ValueOf(a.arg)
-		a.rValue = &v
+func (a *Argument) reflectArg() {
+	if !a.reflected {
+		a.rValue = reflect.ValueOf(a.arg)
+		a.rType = reflect.TypeOf(a.arg)
+		a.reflected = true
 	}
-	return *a.rValue
+}
+
+func (a *Argument) getReflectedValue() reflect.Value {
+	a.reflectArg()
+	return a.rValue
 }
 
 func (a *Argument) getReflectedType() reflect.Type {
-	if a.rType == nil {
-		t := reflect.TypeOf(a.arg)
-		a.rType = &t
-	}
-	return *a.rType
+	a.reflectArg()
+	return a.rType
 }
 
 // GetType will return the type of the argument.
 func (a *Argument) GetType() ParameterType {
 	if a.isNull() {
 		return Null
+	} else if a.isRow() {
+		return Composite
+	} else if a.isRange() {
+		return RangeType
+	} else if a.isNumeric() {
+		return Numeric
+	} else if a.isMoney() {
+		return Money
+	} else if a.isTVP() {
+		return TableValued
 	} else if a.isPtr() {
 		return Pointer
+	} else if a.isGenericArray() {
+		return GenericArray
+	} else if a.isHstore() {
+		return Hstore
+	} else if a.isPgtypeArray() {
+		return Slice
+	} else if a.isValuer() {
+		return Valuer
+	} else if a.isNetwork() {
+		return Network
+	} else if a.isRawJSON() {
+		return JSON
+	} else if a.isDate() {
+		return Date
+	} else if a.isTimeOfDay() {
+		return TimeOfDay
 	} else if a.isTime() {
 		return Time
 	} else if a.isString() {
 		return String
+	} else if a.isUUID() {
+		return UUID
+	} else if a.isBytes() {
+		return Bytes
+	} else if a.isStringer() {
+		return String
 	} else if a.isSlice() {
 		return Slice
-	} else if a.isGenericArray() {
-		return GenericArray
+	} else if a.isMap() {
+		return Map
+	} else if a.isStruct() {
+		return Struct
 	} else if a.isBoolean() {
 		return Boolean
+	} else if a.isFloat() {
+		return Float
+	} else if a.isInteger() {
+		return Integer
+	} else if a.isUnsupported() {
+		return Unsupported
 	}
 	return Integer
 }
 
 func (a *Argument) isNull() bool {
-	return a.arg == nil || (a.getReflectedValue().Kind() == reflect.Ptr && a.getReflectedValue().IsNil())
+	if a.arg == nil {
+		return true
+	}
+	switch a.getReflectedValue().Kind() {
+	case reflect.Ptr, reflect.Map:
+		return a.getReflectedValue().IsNil()
+	case reflect.Slice:
+		return a.getReflectedValue().IsNil() && !a.formatter.nilSliceAsEmpty
+	}
+	return false
 }
 
 func (a *Argument) isPtr() bool {
 	return a.getReflectedValue().Kind() == reflect.Ptr
 }
 
+// isTime reports whether the argument is a time.Time or a civil.DateTime.
 func (a *Argument) isTime() bool {
 	_, ok := a.arg.(time.Time)
-	return ok
+	return ok || isCivil(a.getReflectedType(), "DateTime")
 }
 
 func (a *Argument) isString() bool {
@@ -113,8 +207,22 @@ func (a *Argument) isBoolean() bool {
 	return a.getReflectedType().Kind() == reflect.Bool
 }
 
+func (a *Argument) isBytes() bool {
+	return a.isSlice() && a.getReflectedType().Elem().Kind() == reflect.Uint8
+}
+
+// isUUID reports whether the argument is a [16]byte, which is the underlying
+// type of the common UUID packages (google/uuid, gofrs/uuid, etc.).
+func (a *Argument) isUUID() bool {
+	t := a.getReflectedType()
+	return t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8
+}
+
+// isSlice reports whether the argument is a slice or a Go array, which are
+// both formatted as Postgres arrays.
 func (a *Argument) isSlice() bool {
-	return a.getReflectedType().Kind() == reflect.Slice
+	kind := a.getReflectedType().Kind()
+	return kind == reflect.Slice || kind == reflect.Array
 }
 
 func (a *Argument) isGenericArray() bool {
@@ -122,68 +230,232 @@ func (a *Argument) isGenericArray() bool {
 	return ok
 }
 
-func (a *Argument) format() string {
-	if a.isNull() {
+// isValuer reports whether the argument implements driver.Valuer, as
+// sql.NullString and the guregu/null and volatiletech/null types do.
+func (a *Argument) isValuer() bool {
+	_, ok := a.arg.(driver.Valuer)
+	return ok
+}
+
+func (a *Argument) isMap() bool {
+	return a.getReflectedType().Kind() == reflect.Map
+}
+
+func (a *Argument) isStruct() bool {
+	return a.getReflectedType().Kind() == reflect.Struct
+}
+
+// Format returns the SQL literal of the argument.
+func (a *Argument) Format() string {
+	if !a.inner && a.formatter.redacted(a.index, a.arg) {
+		return a.formatter.quote(redactedValue)
+	} else if marker, ok := a.checkNesting(); ok {
+		return a.formatter.quote(marker)
+	} else if a.isNull() {
 		return a.formatNull()
+	} else if a.isRow() {
+		return a.formatRow()
+	} else if a.isRange() {
+		return a.formatRange()
+	} else if a.isNumeric() {
+		return a.formatNumeric()
+	} else if a.isMoney() {
+		return a.formatMoney()
+	} else if a.isTVP() {
+		return a.formatTVP()
 	} else if a.isPtr() {
 		return a.formatPtr(a.getReflectedValue())
+	} else if a.isGenericArray() {
+		return a.formatGenericArray(a.arg)
+	} else if a.isHstore() {
+		return a.formatHstore()
+	} else if a.isPgtypeArray() {
+		return a.formatPgtypeArray()
+	} else if a.isValuer() {
+		return a.formatValuer()
+	} else if a.isNetwork() {
+		return a.formatNetwork()
+	} else if a.isRawJSON() {
+		return a.formatRawJSON()
+	} else if a.isDate() {
+		return a.formatDate()
+	} else if a.isTimeOfDay() {
+		return a.formatTimeOfDay()
 	} else if a.isTime() {
 		return a.formatTime(a.arg)
 	} else if a.isString() {
-		return a.formatString(a.arg)
+		return a.formatString(a.getReflectedValue().String())
+	} else if a.isUUID() {
+		return a.formatUUID()
+	} else if a.isBytes() {
+		return a.formatBytes()
+	} else if a.isStringer() {
+		return a.formatStringer()
 	} else if a.isSlice() {
 		return a.formatSlice()
-	} else if a.isGenericArray() {
-		return a.formatGenericArray(a.arg)
+	} else if a.isMap() {
+		return a.formatMap()
+	} else if a.isStruct() {
+		return a.formatStruct()
 	} else if a.isBoolean() {
-		return a.formatBoolean(a.arg)
+		return a.formatBoolean()
+	} else if a.isFloat() {
+		return a.formatFloat()
+	} else if a.isInteger() {
+		return a.formatInteger()
+	} else if a.isUnsupported() {
+		return a.formatUnsupported()
 	}
 	return fmt.Sprintf("%v", a.arg)
 }
 
 func (a *Argument) formatSlice() string {
-	var result []string
-	for i := 0; i < a.getReflectedValue().Len(); i++ {
-		newArg := NewArgument(a.getReflectedValue().Index(i).Interface())
-		result = append(result, newArg.format())
+	if dialect, ok := a.formatter.dialect.(ArrayLiteral); ok {
+		return a.formatArrayLiteral(dialect)
+	}
+	array, marker := a.formatArray()
+	return a.formatter.quote(array) + marker
+}
+
+// formatArray returns the Postgres array literal of the slice, before quoting,
+// and its truncation marker. Nested slices become nested arrays, e.g.
+// {{1,2},{3,4}}.
+func (a *Argument) formatArray() (string, string) {
+	var b strings.Builder
+	b.WriteByte('{')
+	n, marker := a.formatter.truncateSlice(a.getReflectedValue().Len())
+	// The elements share an Argument, which escapes to the heap, instead of
+	// allocating one each.
+	var elem Argument
+	ancestors := a.ancestry()
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		elem = Argument{arg: a.getReflectedValue().Index(i).Interface(), formatter: a.formatter, ancestors: ancestors, depth: a.depth + 1, inner: true}
+		b.WriteString(elem.formatArrayElement())
+	}
+	b.WriteByte('}')
+	return b.String(), marker
+}
+
+// formatArrayElement returns the argument as an element of a Postgres array
+// literal. Values formatted as string literals are double quoted instead, so
+// commas, braces and quotes inside them don't break the array.
+func (a *Argument) formatArrayElement() string {
+	if marker, ok := a.checkNesting(); ok {
+		return `"` + arrayElementEscaper.Replace(marker) + `"`
+	} else if a.isNull() {
+		return a.formatNull()
+	} else if a.isPtr() {
+		return a.unwrap(a.getReflectedValue().Elem().Interface()).formatArrayElement()
+	} else if a.isSlice() && !a.isBytes() && !a.isUUID() {
+		array, marker := a.formatArray()
+		return array + marker
+	}
+	s, marker := splitTruncationMarker(a.Format())
+	if v, ok := unquote(uncast(s)); ok {
+		return `"` + arrayElementEscaper.Replace(v) + `"` + marker
+	}
+	return s + marker
+}
+
+func (a *Argument) formatBytes() string {
+	rv := a.getReflectedValue()
+	var b []byte
+	if rv.Kind() == reflect.Array {
+		b = make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+	} else {
+		b = rv.Bytes()
 	}
-	return fmt.Sprintf("'{%s}'", strings.Join(result, ","))
+	return a.formatter.formatBytes(b)
+}
+
+func (a *Argument) formatUUID() string {
+	var u [16]byte
+	reflect.Copy(reflect.ValueOf(u[:]), a.getReflectedValue())
+	h := hex.EncodeToString(u[:])
+	uuid := h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+	if dialect, ok := a.formatter.dialect.(UUIDLiteral); ok {
+		return dialect.FormatUUID(uuid)
+	}
+	return a.formatter.quote(uuid)
 }
 
 func (a *Argument) formatNull() string {
-	return "NULL"
+	return a.formatter.nullLiteral
 }
 
 func (a *Argument) formatPtr(rv reflect.Value) string {
-	return NewArgument(rv.Elem()).format()
+	return a.unwrap(rv.Elem().Interface()).Format()
 }
 
 func (a *Argument) formatTime(arg any) string {
-	t, _ := arg.(time.Time)
-	return NewArgument(t.Format(time.RFC3339)).format()
+	t, ok := arg.(time.Time)
+	if !ok {
+		return a.formatter.cast(a.formatter.quote(fmt.Sprint(arg)), "timestamp")
+	}
+	return a.formatter.formatTime(t)
+}
+
+func (a *Argument) formatString(s string) string {
+	s, marker := a.formatter.truncateString(s)
+	return a.formatter.quote(s) + marker
+}
+
+func (a *Argument) formatBoolean() string {
+	return a.formatter.formatBool(a.getReflectedValue().Bool())
 }
 
-func (a *Argument) formatString(arg any) string {
-	s, _ := arg.(string)
-	return fmt.Sprintf("'%s'", s)
+// formatMap returns the map as a JSON literal, or as a map literal in dialects
+// implementing MapLiteral. encoding/json already sorts the keys, maps with
+// keys it can't handle have their keys converted with fmt.
+func (a *Argument) formatMap() string {
+	if dialect, ok := a.formatter.dialect.(MapLiteral); ok {
+		return a.formatMapLiteral(dialect)
+	}
+	return a.formatNested()
+}
+
+// formatStruct returns the struct as a JSON literal, following the
+// encoding/json rules for tags, embedded fields and omitempty. MarshalJSON
+// methods are used, pointer receivers included, so the literal matches what
+// the application stores in json columns. Dialects implementing StructLiteral,
+// like BigQuery, have struct literals instead.
+func (a *Argument) formatStruct() string {
+	if dialect, ok := a.formatter.dialect.(StructLiteral); ok {
+		return a.formatStructLiteral(dialect)
+	}
+	return a.formatNested()
 }
 
-func (a *Argument) formatBoolean(arg any) string {
-	b, _ := arg.(bool)
-	if b {
-		return "true"
+func (a *Argument) formatNested() string {
+	s, err := a.marshalNested()
+	if err != nil {
+		return a.formatter.quote(fmt.Sprintf("<invalid value: %v>", err))
 	}
-	return "false"
+	return a.formatter.formatJSON(s)
 }
 
+// formatValuer formats the value the driver would receive from Value.
+func (a *Argument) formatValuer() string {
+	v, err := a.arg.(driver.Valuer).Value()
+	if err != nil {
+		return a.formatter.quote(fmt.Sprintf("<invalid value: %v>", err))
+	}
+	return a.nested(v).Format()
+}
+
+// formatGenericArray formats the pq.GenericArray returned by pq.Array for
+// slices without a concrete pq array type. Its Value is the array literal.
 func (a *Argument) formatGenericArray(arg any) string {
-	m, ok := arg.(string)
-	if ok {
-		return m
+	n, err := arg.(pq.GenericArray).Value()
+	if err != nil {
+		return a.formatter.quote(fmt.Sprintf("<invalid value: %v>", err))
 	}
-	n, _ := arg.(pq.GenericArray).Value()
 	if n == nil {
-		return "NULL"
+		return a.formatNull()
 	}
-	return n.(string)
+	return a.formatter.quote(n.(string))
 }
@@ -17,6 +17,8 @@ func (suite *QueryfTestSuite) TestResult() {
 	suite.Equal(Format(`SELECT $1`, arg), `SELECT NULL`)
 	num := 5
 	suite.Equal(Format(`SELECT $1`, &num), `SELECT 5`)
+	name := "John"
+	suite.Equal(Format(`SELECT $1`, &name), `SELECT 'John'`)
 	t, err := time.Parse(time.RFC3339, "2022-02-10T00:00:00Z")
 	suite.Nil(err)
 	suite.Equal(Format(`SELECT $1`, t), `SELECT '2022-02-10T00:00:00Z'`)
@@ -32,3 +34,37 @@ func (suite *QueryfTestSuite) TestResult() {
 func TestQueryfTestSuite(t *testing.T) {
 	suite.Run(t, new(QueryfTestSuite))
 }
+
+func (suite *QueryfTestSuite) TestArrayElements() {
+	suite.Equal(`SELECT '{"a,b","c\"d","e\\f","it''s","{g}"}'`, Format(`SELECT $1`, []string{"a,b", `c"d`, `e\f`, "it's", "{g}"}))
+	suite.Equal(`SELECT '{true,false}'`, Format(`SELECT $1`, []bool{true, false}))
+	suite.Equal(`SELECT '{"\\xdead"}'`, Format(`SELECT $1`, [][]byte{{0xde, 0xad}}))
+	suite.Equal(`SELECT '{"{\"a\":1}"}'`, Format(`SELECT $1`, []map[string]int{{"a": 1}}))
+	suite.Equal(`SELECT '{"2022-02-10T00:00:00Z"}'`, Format(`SELECT $1`, []time.Time{time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)}))
+}
+
+func (suite *QueryfTestSuite) TestNilSlices() {
+	var ints []int
+	var b []byte
+	suite.Equal(`SELECT NULL, '{}', NULL`, Format(`SELECT $1, $2, $3`, ints, []int{}, b))
+	suite.Equal(Null, NewArgument(ints).GetType())
+
+	f := New(WithNilSliceAsEmpty())
+	suite.Equal(`SELECT '{}', '{}', '\x'`, f.Format(`SELECT $1, $2, $3`, ints, []int{}, b))
+}
+
+func (suite *QueryfTestSuite) TestMultidimensionalArrays() {
+	suite.Equal(`SELECT '{{1,2},{3,4}}'`, Format(`SELECT $1`, [][]int{{1, 2}, {3, 4}}))
+	suite.Equal(`SELECT '{{{"a"},{"b"}}}'`, Format(`SELECT $1`, [][][]string{{{"a"}, {"b"}}}))
+	inner := []int{1}
+	suite.Equal(`SELECT '{{1},NULL}'`, Format(`SELECT $1`, []*[]int{&inner, nil}))
+}
+
+func (suite *QueryfTestSuite) TestGoArrays() {
+	suite.Equal(`SELECT '{1,2,3}'`, Format(`SELECT $1`, [3]int{1, 2, 3}))
+	suite.Equal(`SELECT '{"a","b"}'`, Format(`SELECT $1`, [2]string{"a", "b"}))
+	suite.Equal(`SELECT '{{1,2},{3,4}}'`, Format(`SELECT $1`, [2][2]int{{1, 2}, {3, 4}}))
+	suite.Equal(`SELECT '\xdead'`, Format(`SELECT $1`, [2]byte{0xde, 0xad}))
+	suite.Equal(Slice, NewArgument([1]int{1}).GetType())
+	suite.Equal(UUID, NewArgument([16]byte{}).GetType())
+}
@@ -0,0 +1,54 @@
+// Package queryfgorm plugs queryf into GORM, so the queries it logs have their
+// arguments interpolated by queryf instead of GORM's own explainer.
+//
+//	db, _ := gorm.Open(postgres.Open(dsn), &gorm.Config{
+//		Logger: queryfgorm.New(logger.Default),
+//	})
+//
+//	** The logged queries are meant for debugging, never execute them. **
+package queryfgorm
+
+import (
+	"context"
+
+	"github.com/lucastamoios/queryf"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Logger implements GORM's logger.Interface by wrapping another GORM logger.
+// The wrapped logger still decides what gets logged and how, including the
+// highlighting of queries slower than its SlowThreshold, but the SQL it
+// receives is formatted by queryf.
+type Logger struct {
+	logger.Interface
+	// Formatter formats the queries. Defaults to queryf.Format.
+	Formatter *queryf.Formatter
+}
+
+var (
+	_ logger.Interface  = (*Logger)(nil)
+	_ gorm.ParamsFilter = (*Logger)(nil)
+)
+
+// New returns a Logger wrapping l. A nil l wraps logger.Default.
+func New(l logger.Interface) *Logger {
+	if l == nil {
+		l = logger.Default
+	}
+	return &Logger{Interface: l}
+}
+
+// LogMode returns a copy of the logger with the wrapped logger level set.
+func (l *Logger) LogMode(level logger.LogLevel) logger.Interface {
+	return &Logger{Interface: l.Interface.LogMode(level), Formatter: l.Formatter}
+}
+
+// ParamsFilter is called by GORM before explaining a query. It formats the
+// query with queryf and drops the params, leaving nothing for GORM to replace.
+func (l *Logger) ParamsFilter(_ context.Context, sql string, params ...any) (string, []any) {
+	if l.Formatter == nil {
+		return queryf.Format(sql, params...), nil
+	}
+	return l.Formatter.Format(sql, params...), nil
+}
@@ -0,0 +1,104 @@
+package queryfgorm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucastamoios/queryf"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// dialector is a minimal Postgres-like GORM dialector for dry runs.
+type dialector struct{}
+
+func (dialector) Name() string { return "test" }
+
+func (dialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+
+func (dialector) Migrator(*gorm.DB) gorm.Migrator { return nil }
+
+func (dialector) DataTypeOf(*schema.Field) string { return "" }
+
+func (dialector) DefaultValueOf(*schema.Field) clause.Expression { return clause.Expr{} }
+
+func (dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, _ any) {
+	writer.WriteString(fmt.Sprintf("$%d", len(stmt.Vars)))
+}
+
+func (dialector) QuoteTo(writer clause.Writer, str string) {
+	writer.WriteString(`"` + str + `"`)
+}
+
+func (dialector) Explain(sql string, vars ...any) string {
+	return logger.ExplainSQL(sql, nil, `'`, vars...)
+}
+
+type writer struct {
+	lines []string
+}
+
+func (w *writer) Printf(format string, args ...any) {
+	w.lines = append(w.lines, fmt.Sprintf(format, args...))
+}
+
+type user struct {
+	ID   int
+	Name string
+}
+
+type LoggerTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LoggerTestSuite) open(l logger.Interface) *gorm.DB {
+	db, err := gorm.Open(dialector{}, &gorm.Config{DryRun: true, Logger: l})
+	suite.Require().Nil(err)
+	return db
+}
+
+func (suite *LoggerTestSuite) TestFormatsQueries() {
+	w := &writer{}
+	db := suite.open(New(logger.New(w, logger.Config{LogLevel: logger.Info})))
+	db.Where("name = ? AND id > ?", "John", 3).Find(&[]user{})
+
+	suite.Require().Len(w.lines, 1)
+	suite.True(strings.HasSuffix(w.lines[0], `SELECT * FROM "users" WHERE name = 'John' AND id > 3`), w.lines[0])
+}
+
+func (suite *LoggerTestSuite) TestLogMode() {
+	w := &writer{}
+	db := suite.open(New(logger.New(w, logger.Config{LogLevel: logger.Info})))
+	db.Session(&gorm.Session{Logger: db.Logger.LogMode(logger.Silent)}).Find(&[]user{})
+	suite.Empty(w.lines)
+
+	l := &Logger{Interface: logger.New(w, logger.Config{}), Formatter: queryf.New(queryf.WithNullLiteral("null"))}
+	suite.Equal(l.Formatter, l.LogMode(logger.Info).(*Logger).Formatter)
+}
+
+func (suite *LoggerTestSuite) TestSlowQueries() {
+	w := &writer{}
+	l := New(logger.New(w, logger.Config{LogLevel: logger.Warn, SlowThreshold: time.Nanosecond}))
+	l.Trace(context.Background(), time.Now().Add(-time.Second), func() (string, int64) {
+		sql, _ := l.ParamsFilter(context.Background(), `SELECT $1`, "John")
+		return sql, 1
+	}, nil)
+
+	suite.Require().Len(w.lines, 1)
+	suite.Contains(w.lines[0], "SLOW SQL")
+	suite.Contains(w.lines[0], `SELECT 'John'`)
+}
+
+func TestLoggerTestSuite(t *testing.T) {
+	suite.Run(t, new(LoggerTestSuite))
+}
@@ -0,0 +1,18 @@
+package queryfpgx
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/lucastamoios/queryf"
+)
+
+// Statements returns the queries queued in the batch, to be formatted with
+// queryf.FormatBatch or queryf.FormatScript.
+//
+//	fmt.Println(queryf.FormatScript(queryfpgx.Statements(batch)))
+func Statements(b *pgx.Batch) []queryf.Statement {
+	stmts := make([]queryf.Statement, len(b.QueuedQueries))
+	for i, q := range b.QueuedQueries {
+		stmts[i] = queryf.Statement{Query: q.SQL, Args: q.Arguments}
+	}
+	return stmts
+}
@@ -0,0 +1,31 @@
+package queryfpgx
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucastamoios/queryf"
+	"github.com/stretchr/testify/suite"
+)
+
+type BatchTestSuite struct {
+	suite.Suite
+}
+
+func (suite *BatchTestSuite) TestStatements() {
+	batch := &pgx.Batch{}
+	batch.Queue(`SELECT * FROM users WHERE id = $1`, 1)
+	batch.Queue(`DELETE FROM sessions WHERE user_id = $1 AND token = $2`, 1, "abc")
+
+	suite.Equal([]queryf.Statement{
+		{Query: `SELECT * FROM users WHERE id = $1`, Args: []any{1}},
+		{Query: `DELETE FROM sessions WHERE user_id = $1 AND token = $2`, Args: []any{1, "abc"}},
+	}, Statements(batch))
+	suite.Equal("SELECT * FROM users WHERE id = 1;\nDELETE FROM sessions WHERE user_id = 1 AND token = 'abc'",
+		queryf.FormatScript(Statements(batch)))
+	suite.Empty(Statements(&pgx.Batch{}))
+}
+
+func TestBatchTestSuite(t *testing.T) {
+	suite.Run(t, new(BatchTestSuite))
+}
@@ -0,0 +1,76 @@
+// Package queryfpgx plugs queryf into pgx v5, logging every query with its
+// arguments interpolated.
+//
+//	config, _ := pgxpool.ParseConfig(os.Getenv("DATABASE_URL"))
+//	config.ConnConfig.Tracer = &queryfpgx.Tracer{}
+//	pool, _ := pgxpool.NewWithConfig(ctx, config)
+//
+//	** The logged queries are meant for debugging, never execute them. **
+package queryfpgx
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucastamoios/queryf"
+)
+
+// LogFunc is called once a query finishes, with the formatted query, how long
+// it took and the error it returned, if any.
+type LogFunc func(ctx context.Context, query string, duration time.Duration, err error)
+
+// Tracer implements pgx.QueryTracer. The zero value is ready to use and logs
+// with the standard log package.
+type Tracer struct {
+	// Formatter formats the queries. Defaults to queryf.Format.
+	Formatter *queryf.Formatter
+	// Log is called when each query ends. Defaults to log.Printf.
+	Log LogFunc
+}
+
+var _ pgx.QueryTracer = (*Tracer)(nil)
+
+type contextKey struct{}
+
+type traceData struct {
+	query string
+	start time.Time
+}
+
+// TraceQueryStart formats the query while its arguments are available.
+func (t *Tracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, contextKey{}, traceData{
+		query: t.format(data.SQL, data.Args),
+		start: time.Now(),
+	})
+}
+
+// TraceQueryEnd logs the query formatted by TraceQueryStart.
+func (t *Tracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	trace, ok := ctx.Value(contextKey{}).(traceData)
+	if !ok {
+		return
+	}
+	t.log(ctx, trace.query, time.Since(trace.start), data.Err)
+}
+
+func (t *Tracer) format(query string, args []any) string {
+	if t.Formatter == nil {
+		return queryf.Format(query, args...)
+	}
+	return t.Formatter.Format(query, args...)
+}
+
+func (t *Tracer) log(ctx context.Context, query string, duration time.Duration, err error) {
+	if t.Log != nil {
+		t.Log(ctx, query, duration, err)
+		return
+	}
+	if err != nil {
+		log.Printf("query failed after %s: %s: %v", duration, query, err)
+		return
+	}
+	log.Printf("query took %s: %s", duration, query)
+}
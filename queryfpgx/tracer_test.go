@@ -0,0 +1,62 @@
+package queryfpgx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lucastamoios/queryf"
+	"github.com/stretchr/testify/suite"
+)
+
+type TracerTestSuite struct {
+	suite.Suite
+}
+
+type logged struct {
+	query string
+	err   error
+}
+
+func (suite *TracerTestSuite) trace(tracer *Tracer, data pgx.TraceQueryStartData, err error) {
+	ctx := tracer.TraceQueryStart(context.Background(), nil, data)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: err})
+}
+
+func (suite *TracerTestSuite) TestLogsFormattedQuery() {
+	var got []logged
+	tracer := &Tracer{Log: func(_ context.Context, query string, _ time.Duration, err error) {
+		got = append(got, logged{query, err})
+	}}
+	failure := errors.New("boom")
+
+	suite.trace(tracer, pgx.TraceQueryStartData{SQL: `SELECT $1, $2`, Args: []any{1, "John"}}, nil)
+	suite.trace(tracer, pgx.TraceQueryStartData{SQL: `SELECT $1`, Args: []any{nil}}, failure)
+
+	suite.Equal([]logged{{`SELECT 1, 'John'`, nil}, {`SELECT NULL`, failure}}, got)
+}
+
+func (suite *TracerTestSuite) TestFormatter() {
+	var got string
+	tracer := &Tracer{
+		Formatter: queryf.New(queryf.WithNullLiteral("null")),
+		Log: func(_ context.Context, query string, _ time.Duration, _ error) {
+			got = query
+		},
+	}
+	suite.trace(tracer, pgx.TraceQueryStartData{SQL: `SELECT $1`, Args: []any{nil}}, nil)
+	suite.Equal(`SELECT null`, got)
+}
+
+func (suite *TracerTestSuite) TestEndWithoutStart() {
+	called := false
+	tracer := &Tracer{Log: func(context.Context, string, time.Duration, error) { called = true }}
+	tracer.TraceQueryEnd(context.Background(), nil, pgx.TraceQueryEndData{})
+	suite.False(called)
+}
+
+func TestTracerTestSuite(t *testing.T) {
+	suite.Run(t, new(TracerTestSuite))
+}
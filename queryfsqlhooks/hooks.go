@@ -0,0 +1,75 @@
+// Package queryfsqlhooks plugs queryf into github.com/qustavo/sqlhooks,
+// logging every query with its arguments interpolated.
+//
+//	sql.Register("postgres-queryf", sqlhooks.Wrap(&pq.Driver{}, &queryfsqlhooks.Hooks{}))
+//	db, _ := sql.Open("postgres-queryf", os.Getenv("DATABASE_URL"))
+//
+//	** The logged queries are meant for debugging, never execute them. **
+package queryfsqlhooks
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/lucastamoios/queryf"
+	"github.com/qustavo/sqlhooks/v2"
+)
+
+// LogFunc is called once a query finishes, with the formatted query, how long
+// it took and the error it returned, if any.
+type LogFunc func(ctx context.Context, query string, duration time.Duration, err error)
+
+// Hooks implements sqlhooks.Hooks and sqlhooks.OnErrorer. The zero value is
+// ready to use and logs with the standard log package.
+type Hooks struct {
+	// Formatter formats the queries. Defaults to queryf.Format.
+	Formatter *queryf.Formatter
+	// Log is called when each query ends. Defaults to log.Printf.
+	Log LogFunc
+}
+
+var (
+	_ sqlhooks.Hooks     = (*Hooks)(nil)
+	_ sqlhooks.OnErrorer = (*Hooks)(nil)
+)
+
+type contextKey struct{}
+
+// Before records when the query started.
+func (h *Hooks) Before(ctx context.Context, _ string, _ ...any) (context.Context, error) {
+	return context.WithValue(ctx, contextKey{}, time.Now()), nil
+}
+
+// After logs the query formatted.
+func (h *Hooks) After(ctx context.Context, query string, args ...any) (context.Context, error) {
+	h.log(ctx, query, args, nil)
+	return ctx, nil
+}
+
+// OnError logs the failed query formatted, and returns err unchanged.
+func (h *Hooks) OnError(ctx context.Context, err error, query string, args ...any) error {
+	h.log(ctx, query, args, err)
+	return err
+}
+
+func (h *Hooks) log(ctx context.Context, query string, args []any, err error) {
+	var duration time.Duration
+	if start, ok := ctx.Value(contextKey{}).(time.Time); ok {
+		duration = time.Since(start)
+	}
+	if h.Formatter == nil {
+		query = queryf.Format(query, args...)
+	} else {
+		query = h.Formatter.Format(query, args...)
+	}
+	if h.Log != nil {
+		h.Log(ctx, query, duration, err)
+		return
+	}
+	if err != nil {
+		log.Printf("query failed after %s: %s: %v", duration, query, err)
+		return
+	}
+	log.Printf("query took %s: %s", duration, query)
+}
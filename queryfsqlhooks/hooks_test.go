@@ -0,0 +1,67 @@
+package queryfsqlhooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucastamoios/queryf"
+	"github.com/stretchr/testify/suite"
+)
+
+type HooksTestSuite struct {
+	suite.Suite
+}
+
+type logged struct {
+	query string
+	err   error
+}
+
+func (suite *HooksTestSuite) TestLogsFormattedQuery() {
+	var got []logged
+	hooks := &Hooks{Log: func(_ context.Context, query string, _ time.Duration, err error) {
+		got = append(got, logged{query, err})
+	}}
+	failure := errors.New("boom")
+
+	ctx, err := hooks.Before(context.Background(), `SELECT $1, $2`, 1, "John")
+	suite.Require().NoError(err)
+	_, err = hooks.After(ctx, `SELECT $1, $2`, 1, "John")
+	suite.Require().NoError(err)
+
+	ctx, err = hooks.Before(context.Background(), `SELECT $1`, nil)
+	suite.Require().NoError(err)
+	suite.Equal(failure, hooks.OnError(ctx, failure, `SELECT $1`, nil))
+
+	suite.Equal([]logged{{`SELECT 1, 'John'`, nil}, {`SELECT NULL`, failure}}, got)
+}
+
+func (suite *HooksTestSuite) TestFormatter() {
+	var got string
+	hooks := &Hooks{
+		Formatter: queryf.New(queryf.WithNullLiteral("null")),
+		Log: func(_ context.Context, query string, _ time.Duration, _ error) {
+			got = query
+		},
+	}
+	ctx, _ := hooks.Before(context.Background(), `SELECT $1`, nil)
+	_, _ = hooks.After(ctx, `SELECT $1`, nil)
+	suite.Equal(`SELECT null`, got)
+}
+
+func (suite *HooksTestSuite) TestDuration() {
+	var duration time.Duration
+	hooks := &Hooks{Log: func(_ context.Context, _ string, d time.Duration, _ error) {
+		duration = d
+	}}
+	ctx, _ := hooks.Before(context.Background(), `SELECT 1`)
+	time.Sleep(time.Millisecond)
+	_, _ = hooks.After(ctx, `SELECT 1`)
+	suite.GreaterOrEqual(duration, time.Millisecond)
+}
+
+func TestHooksTestSuite(t *testing.T) {
+	suite.Run(t, new(HooksTestSuite))
+}
@@ -0,0 +1,36 @@
+// Package queryftest provides assertions on SQL queries for unit tests, e.g.
+// of repositories or query builders, that don't break when the queries are
+// reformatted.
+//
+//	func TestFindUser(t *testing.T) {
+//		query, args := repo.FindUserQuery("John")
+//		queryftest.AssertFormats(t, query, args, "SELECT * FROM users WHERE name = 'John'")
+//	}
+package queryftest
+
+import (
+	"testing"
+
+	"github.com/lucastamoios/queryf"
+)
+
+// AssertEqualSQL checks that the queries are the same SQL, ignoring
+// whitespace, line breaks, keyword case and the spaces around operators, as
+// queryf.Diff does. It reports the lines that differ otherwise, and returns
+// whether the queries are the same.
+func AssertEqualSQL(t testing.TB, expected, actual string) bool {
+	t.Helper()
+	if diff := queryf.Diff(expected, actual); diff != "" {
+		t.Errorf("queries differ (- expected, + actual):\n%s", diff)
+		return false
+	}
+	return true
+}
+
+// AssertFormats checks that the query, formatted with its arguments by
+// queryf.Format, is the same SQL as expected. See AssertEqualSQL for the
+// differences that are ignored.
+func AssertFormats(t testing.TB, query string, args []any, expected string) bool {
+	t.Helper()
+	return AssertEqualSQL(t, expected, queryf.Format(query, args...))
+}
@@ -0,0 +1,53 @@
+package queryftest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AssertTestSuite struct {
+	suite.Suite
+}
+
+// recordingT records the errors reported to it instead of failing the test.
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (suite *AssertTestSuite) TestAssertEqualSQL() {
+	t := &recordingT{}
+	suite.True(AssertEqualSQL(t, "SELECT * FROM users WHERE id = 1", "select *\n  from users\n where id=1"))
+	suite.Empty(t.errors)
+
+	suite.False(AssertEqualSQL(t, "SELECT * FROM users WHERE id = 1", "SELECT * FROM users WHERE id = 2"))
+	suite.Equal([]string{`queries differ (- expected, + actual):
+  SELECT *
+  FROM users
+- WHERE id = 1
++ WHERE id = 2`}, t.errors)
+}
+
+func (suite *AssertTestSuite) TestAssertFormats() {
+	t := &recordingT{}
+	born := time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)
+	suite.True(AssertFormats(t, "INSERT INTO users (name, born_at) VALUES ($1, $2)", []any{"John", born},
+		"insert into users (name, born_at)\nvalues ('John', '2022-02-10T00:00:00Z')"))
+	suite.Empty(t.errors)
+
+	suite.False(AssertFormats(t, "SELECT * FROM users WHERE name = $1", []any{"Jane"}, "SELECT * FROM users WHERE name = 'John'"))
+	suite.Len(t.errors, 1)
+}
+
+func TestAssertTestSuite(t *testing.T) {
+	suite.Run(t, new(AssertTestSuite))
+}
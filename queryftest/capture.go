@@ -0,0 +1,136 @@
+package queryftest
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucastamoios/queryf"
+)
+
+// CaptureLogger records the queries formatted during a test, so tests can
+// check the SQL the code under test runs instead of its results. It is safe
+// for concurrent use.
+//
+// Example:
+//
+//	capture := queryftest.NewCaptureLogger(t)
+//	repo := NewRepository(capture.WrapDB(db))
+//	repo.CreateUser(ctx, "John")
+//	capture.ExpectContains("INSERT INTO users")
+type CaptureLogger struct {
+	t       testing.TB
+	mu      sync.Mutex
+	queries []string
+}
+
+// NewCaptureLogger returns a CaptureLogger reporting its failed expectations
+// to t.
+func NewCaptureLogger(t testing.TB) *CaptureLogger {
+	return &CaptureLogger{t: t}
+}
+
+// WrapDB returns db wrapped by queryf.WrapDB, capturing every query it runs.
+// The options are applied first, so only the threshold and the logger are
+// overridden.
+func (c *CaptureLogger) WrapDB(db *sql.DB, opts ...queryf.DBOption) *queryf.SlowDB {
+	opts = append(opts, queryf.WithSlowThreshold(0), queryf.WithLogger(c.Logger()))
+	return queryf.WrapDB(db, opts...)
+}
+
+// Logger returns a logger capturing the "query" attribute of its records, as
+// written by queryf.Attr and queryf.WrapDB.
+func (c *CaptureLogger) Logger() *slog.Logger {
+	return slog.New(captureHandler{c})
+}
+
+// Format formats the query like queryf.Format and captures it.
+func (c *CaptureLogger) Format(query string, args ...any) string {
+	formatted := queryf.Format(query, args...)
+	c.Record(formatted)
+	return formatted
+}
+
+// Log captures the query, ignoring the rest. It matches queryfpgx.LogFunc, so
+// it can be the Log of a queryfpgx.Tracer.
+func (c *CaptureLogger) Log(_ context.Context, query string, _ time.Duration, _ error) {
+	c.Record(query)
+}
+
+// Record captures a formatted query.
+func (c *CaptureLogger) Record(query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queries = append(c.queries, query)
+}
+
+// Queries returns the captured queries, in the order they were captured.
+func (c *CaptureLogger) Queries() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.queries...)
+}
+
+// Count returns the number of captured queries.
+func (c *CaptureLogger) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.queries)
+}
+
+// Reset forgets the captured queries.
+func (c *CaptureLogger) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queries = nil
+}
+
+// ExpectContains checks that a captured query contains substr, and returns
+// whether one does.
+func (c *CaptureLogger) ExpectContains(substr string) bool {
+	c.t.Helper()
+	queries := c.Queries()
+	for _, query := range queries {
+		if strings.Contains(query, substr) {
+			return true
+		}
+	}
+	c.t.Errorf("no query contains %q, captured:\n%s", substr, strings.Join(queries, "\n"))
+	return false
+}
+
+// ExpectCount checks that n queries were captured, and returns whether they
+// were.
+func (c *CaptureLogger) ExpectCount(n int) bool {
+	c.t.Helper()
+	queries := c.Queries()
+	if len(queries) != n {
+		c.t.Errorf("captured %d queries, expected %d:\n%s", len(queries), n, strings.Join(queries, "\n"))
+		return false
+	}
+	return true
+}
+
+// captureHandler is the slog.Handler of CaptureLogger.Logger.
+type captureHandler struct {
+	c *CaptureLogger
+}
+
+func (h captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h captureHandler) Handle(_ context.Context, r slog.Record) error {
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == "query" {
+			h.c.Record(a.Value.Resolve().String())
+		}
+		return true
+	})
+	return nil
+}
+
+func (h captureHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h captureHandler) WithGroup(string) slog.Handler      { return h }
@@ -0,0 +1,68 @@
+package queryftest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+
+	"github.com/lucastamoios/queryf"
+)
+
+// execConnector opens connections that accept every Exec and nothing else.
+type execConnector struct{}
+
+func (execConnector) Connect(context.Context) (driver.Conn, error) { return execConn{}, nil }
+func (execConnector) Driver() driver.Driver                        { return nil }
+
+type execConn struct{}
+
+func (execConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (execConn) Close() error                        { return nil }
+func (execConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (execConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (suite *AssertTestSuite) TestCaptureLoggerWrapDB() {
+	capture := NewCaptureLogger(suite.T())
+	db := capture.WrapDB(sql.OpenDB(execConnector{}))
+	defer db.Close()
+
+	_, err := db.Exec("INSERT INTO users (name) VALUES ($1)", "John")
+	suite.Require().NoError(err)
+	_, err = db.Exec("DELETE FROM users WHERE id = $1", 1)
+	suite.Require().NoError(err)
+
+	suite.Equal(2, capture.Count())
+	suite.Equal([]string{"INSERT INTO users (name) VALUES ('John')", "DELETE FROM users WHERE id = 1"}, capture.Queries())
+	suite.True(capture.ExpectContains("INSERT INTO users"))
+	suite.True(capture.ExpectCount(2))
+
+	capture.Reset()
+	suite.Zero(capture.Count())
+}
+
+func (suite *AssertTestSuite) TestCaptureLoggerSources() {
+	capture := NewCaptureLogger(suite.T())
+	capture.Logger().Info("running query", queryf.Attr("SELECT * FROM users WHERE id = $1", 1))
+	capture.Logger().Info("other message", slog.Int("id", 1))
+	suite.Equal("SELECT 1", capture.Format("SELECT $1", 1))
+	capture.Log(context.Background(), "SELECT 2", 0, nil)
+	suite.Equal([]string{"SELECT * FROM users WHERE id = 1", "SELECT 1", "SELECT 2"}, capture.Queries())
+}
+
+func (suite *AssertTestSuite) TestCaptureLoggerExpectations() {
+	t := &recordingT{}
+	capture := NewCaptureLogger(t)
+	capture.Record("SELECT * FROM users")
+
+	suite.False(capture.ExpectContains("DELETE"))
+	suite.False(capture.ExpectCount(2))
+	suite.Equal([]string{
+		"no query contains \"DELETE\", captured:\nSELECT * FROM users",
+		"captured 1 queries, expected 2:\nSELECT * FROM users",
+	}, t.errors)
+}
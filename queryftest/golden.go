@@ -0,0 +1,52 @@
+package queryftest
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lucastamoios/queryf"
+)
+
+var update = flag.Bool("update", false, "update the golden files of queryftest.MatchGolden")
+
+// MatchGolden checks that the query, formatted with its arguments, is the same
+// SQL as the golden file testdata/<name>.golden, so changes to the generated
+// queries show up as test failures. The golden files are written, laid out by
+// queryf.Pretty, when the tests run with the -update flag:
+//
+//	go test ./... -update
+//
+// See AssertEqualSQL for the differences that are ignored. It returns whether
+// the query matches the golden file.
+func MatchGolden(t testing.TB, name, query string, args ...any) bool {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := writeGolden(path, queryf.Pretty(query, args...)+"\n"); err != nil {
+			t.Errorf("updating golden file: %v", err)
+			return false
+		}
+		return true
+	}
+	golden, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("golden file %s doesn't exist, run the test with -update to create it", path)
+		return false
+	}
+	if err != nil {
+		t.Errorf("reading golden file: %v", err)
+		return false
+	}
+	return AssertEqualSQL(t, string(golden), queryf.Format(query, args...))
+}
+
+func writeGolden(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
@@ -0,0 +1,35 @@
+package queryftest
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func (suite *AssertTestSuite) TestMatchGolden() {
+	t := &recordingT{}
+	suite.True(MatchGolden(t, "active_users", "SELECT id, name FROM users WHERE active = $1 AND age > $2", true, 18))
+	suite.Empty(t.errors)
+
+	suite.False(MatchGolden(t, "active_users", "SELECT id, name FROM users WHERE active = $1 AND age > $2", true, 21))
+	suite.Len(t.errors, 1)
+
+	t = &recordingT{}
+	suite.False(MatchGolden(t, "missing", "SELECT 1"))
+	suite.Equal([]string{"golden file testdata/missing.golden doesn't exist, run the test with -update to create it"}, t.errors)
+}
+
+func (suite *AssertTestSuite) TestMatchGoldenUpdate() {
+	wd, err := os.Getwd()
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.Chdir(suite.T().TempDir()))
+	defer os.Chdir(wd)
+	*update = true
+	defer func() { *update = false }()
+
+	t := &recordingT{}
+	suite.True(MatchGolden(t, "users/by_id", "SELECT * FROM users WHERE id = $1", 1))
+	suite.Empty(t.errors)
+	golden, err := os.ReadFile(filepath.Join("testdata", "users", "by_id.golden"))
+	suite.Require().NoError(err)
+	suite.Equal("SELECT *\nFROM users\nWHERE id = 1\n", string(golden))
+}
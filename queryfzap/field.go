@@ -0,0 +1,19 @@
+// Package queryfzap plugs queryf into zap, attaching formatted queries to log
+// entries as structured fields.
+//
+//	logger.Debug("running query", queryfzap.Field(query, args...))
+//
+//	** The logged queries are meant for debugging, never execute them. **
+package queryfzap
+
+import (
+	"github.com/lucastamoios/queryf"
+	"go.uber.org/zap"
+)
+
+// Field returns a "query" field with the query formatted by queryf.Format. The
+// query is only formatted when the entry is written, so it costs nothing if
+// the level is disabled.
+func Field(query string, args ...any) zap.Field {
+	return zap.Stringer("query", queryf.Lazy(query, args...))
+}
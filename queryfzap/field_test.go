@@ -0,0 +1,44 @@
+package queryfzap
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type FieldTestSuite struct {
+	suite.Suite
+}
+
+type countingValuer struct {
+	calls *int
+}
+
+func (v countingValuer) Value() (driver.Value, error) {
+	*v.calls++
+	return "x", nil
+}
+
+func (suite *FieldTestSuite) TestField() {
+	core, logs := observer.New(zapcore.InfoLevel)
+	zap.New(core).Info("running query", Field(`SELECT $1, $2`, 1, "John"))
+
+	entries := logs.All()
+	suite.Require().Len(entries, 1)
+	suite.Equal(map[string]any{"query": `SELECT 1, 'John'`}, entries[0].ContextMap())
+}
+
+func (suite *FieldTestSuite) TestLazy() {
+	var calls int
+	core, _ := observer.New(zapcore.InfoLevel)
+	zap.New(core).Debug("running query", Field(`SELECT $1`, countingValuer{&calls}))
+	suite.Equal(0, calls)
+}
+
+func TestFieldTestSuite(t *testing.T) {
+	suite.Run(t, new(FieldTestSuite))
+}
@@ -0,0 +1,26 @@
+// Package queryfzerolog plugs queryf into zerolog, attaching formatted queries
+// to log events as structured fields.
+//
+//	logger.Debug().EmbedObject(queryfzerolog.Marshal(query, args...)).Msg("running query")
+//
+//	** The logged queries are meant for debugging, never execute them. **
+package queryfzerolog
+
+import (
+	"github.com/lucastamoios/queryf"
+	"github.com/rs/zerolog"
+)
+
+type query queryf.Query
+
+// Marshal returns a zerolog.LogObjectMarshaler that adds a "query" field with
+// the query formatted by queryf.Format. The query is only formatted when the
+// event is enabled, so it costs nothing if the level is disabled.
+func Marshal(sql string, args ...any) zerolog.LogObjectMarshaler {
+	return query{SQL: sql, Args: args}
+}
+
+// MarshalZerologObject adds the formatted query to the event.
+func (q query) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("query", queryf.Format(q.SQL, q.Args...))
+}
@@ -0,0 +1,48 @@
+package queryfzerolog
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+)
+
+type MarshalTestSuite struct {
+	suite.Suite
+}
+
+type countingValuer struct {
+	calls *int
+}
+
+func (v countingValuer) Value() (driver.Value, error) {
+	*v.calls++
+	return "x", nil
+}
+
+func (suite *MarshalTestSuite) TestEmbedObject() {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	logger.Info().EmbedObject(Marshal(`SELECT $1, $2`, 1, "John")).Msg("running query")
+	suite.Equal(`{"level":"info","query":"SELECT 1, 'John'","message":"running query"}`+"\n", buf.String())
+}
+
+func (suite *MarshalTestSuite) TestObject() {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	logger.Info().Object("db", Marshal(`SELECT $1`, nil)).Send()
+	suite.Equal(`{"level":"info","db":{"query":"SELECT NULL"}}`+"\n", buf.String())
+}
+
+func (suite *MarshalTestSuite) TestLazy() {
+	var calls int
+	logger := zerolog.New(&bytes.Buffer{}).Level(zerolog.InfoLevel)
+	logger.Debug().EmbedObject(Marshal(`SELECT $1`, countingValuer{&calls})).Msg("running query")
+	suite.Equal(0, calls)
+}
+
+func TestMarshalTestSuite(t *testing.T) {
+	suite.Run(t, new(MarshalTestSuite))
+}
@@ -0,0 +1,93 @@
+package queryf
+
+import "strings"
+
+// Range is a Postgres range argument, e.g. int4range or tstzrange. A nil Lower
+// or Upper is an unbounded side. Bounds sets if each side is inclusive, [, or
+// exclusive, (, and defaults to "[)". Bounds "empty" is the empty range.
+//
+// Example:
+//
+//	fmt.Println(queryf.Format("SELECT $1::int4range", queryf.Range{Lower: 1, Upper: 10}))
+//	// Output: SELECT '[1,10)'::int4range
+type Range struct {
+	Lower  any
+	Upper  any
+	Bounds string
+}
+
+const (
+	pgtypeInclusive = 'i'
+	pgtypeUnbounded = 'U'
+	pgtypeEmpty     = 'E'
+)
+
+func (a *Argument) isRange() bool {
+	if _, ok := a.arg.(Range); ok {
+		return true
+	}
+	return isNamedType(a.getReflectedType(), pgtypePkgPath, "Range")
+}
+
+func (a *Argument) formatRange() string {
+	r, ok := a.arg.(Range)
+	if !ok {
+		var valid bool
+		r, valid = a.pgtypeRange()
+		if !valid {
+			return a.formatNull()
+		}
+	}
+	if r.Bounds == "empty" {
+		return a.formatter.quote("empty")
+	}
+	bounds := r.Bounds
+	if len(bounds) != 2 || !strings.ContainsRune("[(", rune(bounds[0])) || !strings.ContainsRune("])", rune(bounds[1])) {
+		bounds = "[)"
+	}
+	literal := bounds[:1] + a.formatRangeBound(r.Lower) + "," + a.formatRangeBound(r.Upper) + bounds[1:]
+	return a.formatter.quote(literal)
+}
+
+// pgtypeRange converts a pgtype.Range to a Range, reporting false when it is
+// not valid.
+func (a *Argument) pgtypeRange() (Range, bool) {
+	rv := a.getReflectedValue()
+	if !rv.FieldByName("Valid").Bool() {
+		return Range{}, false
+	}
+	lowerType := rv.FieldByName("LowerType").Uint()
+	upperType := rv.FieldByName("UpperType").Uint()
+	if lowerType == pgtypeEmpty || upperType == pgtypeEmpty {
+		return Range{Bounds: "empty"}, true
+	}
+
+	r := Range{Bounds: "()"}
+	if lowerType != pgtypeUnbounded {
+		r.Lower = rv.FieldByName("Lower").Interface()
+		if lowerType == pgtypeInclusive {
+			r.Bounds = "[" + r.Bounds[1:]
+		}
+	}
+	if upperType != pgtypeUnbounded {
+		r.Upper = rv.FieldByName("Upper").Interface()
+		if upperType == pgtypeInclusive {
+			r.Bounds = r.Bounds[:1] + "]"
+		}
+	}
+	return r, true
+}
+
+// formatRangeBound returns the text representation of a range bound, which is
+// empty for unbounded sides and double quoted for string literals.
+func (a *Argument) formatRangeBound(bound any) string {
+	arg := a.nested(bound)
+	if arg.isNull() {
+		return ""
+	}
+	s := arg.Format()
+	if v, ok := unquote(uncast(s)); ok {
+		return `"` + arrayElementEscaper.Replace(v) + `"`
+	}
+	return s
+}
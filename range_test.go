@@ -0,0 +1,46 @@
+package queryf
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (suite *QueryfTestSuite) TestRange() {
+	suite.Equal(`SELECT '[1,10)'`, Format(`SELECT $1`, Range{Lower: 1, Upper: 10}))
+	suite.Equal(`SELECT '(1,10]'`, Format(`SELECT $1`, Range{Lower: 1, Upper: 10, Bounds: "(]"}))
+	suite.Equal(`SELECT '[1,)'`, Format(`SELECT $1`, Range{Lower: 1}))
+	suite.Equal(`SELECT '[,)'`, Format(`SELECT $1`, Range{}))
+	suite.Equal(`SELECT 'empty'`, Format(`SELECT $1`, Range{Bounds: "empty"}))
+	suite.Equal(`SELECT '[1,2)'`, Format(`SELECT $1`, Range{Lower: 1, Upper: 2, Bounds: "<>"}))
+	suite.Equal(`SELECT '["a","it''s \"b\"")'`, Format(`SELECT $1`, Range{Lower: "a", Upper: `it's "b"`}))
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.Equal(`SELECT '["2023-01-01T00:00:00Z","2023-02-01T00:00:00Z")'`, Format(`SELECT $1`, Range{Lower: start, Upper: start.AddDate(0, 1, 0)}))
+	suite.Equal(`SELECT '["2023-01-01","2023-02-01")'`, New(WithTimeFormat("2006-01-02")).Format(`SELECT $1`, Range{Lower: start, Upper: start.AddDate(0, 1, 0)}))
+	suite.Equal(`SELECT '[1,10)'`, Format(`SELECT $1`, &Range{Lower: 1, Upper: 10}))
+	suite.Equal(RangeType, NewArgument(Range{}).GetType())
+}
+
+func (suite *QueryfTestSuite) TestPgtypeRange() {
+	r := pgtype.Range[pgtype.Int4]{
+		Lower:     pgtype.Int4{Int32: 1, Valid: true},
+		Upper:     pgtype.Int4{Int32: 10, Valid: true},
+		LowerType: pgtype.Inclusive,
+		UpperType: pgtype.Exclusive,
+		Valid:     true,
+	}
+	suite.Equal(`SELECT '[1,10)'`, Format(`SELECT $1`, r))
+
+	r.LowerType, r.UpperType = pgtype.Exclusive, pgtype.Inclusive
+	suite.Equal(`SELECT '(1,10]'`, Format(`SELECT $1`, r))
+
+	r.LowerType = pgtype.Unbounded
+	suite.Equal(`SELECT '(,10]'`, Format(`SELECT $1`, r))
+
+	r.LowerType, r.UpperType = pgtype.Empty, pgtype.Empty
+	suite.Equal(`SELECT 'empty'`, Format(`SELECT $1`, r))
+
+	suite.Equal(`SELECT NULL`, Format(`SELECT $1`, pgtype.Range[pgtype.Int4]{}))
+	suite.Equal(RangeType, NewArgument(pgtype.Range[int32]{}).GetType())
+}
@@ -0,0 +1,50 @@
+package queryf
+
+import "strconv"
+
+// Rebind returns the query with its placeholders converted from one style to
+// another, leaving string literals, quoted identifiers and comments untouched.
+// Named placeholders are numbered in order of first appearance, and positional
+// placeholders become :arg1, :arg2, etc. when converted to Named. Converting to
+// Question loses the order of reordered or repeated placeholders, since each ?
+// is bound in order. The escaped ?? of Question queries become ?, but a ? in
+// queries converted to Question isn't escaped.
+//
+// Example:
+//
+//	fmt.Println(queryf.Rebind("SELECT * FROM users WHERE id = ? AND name = ?", queryf.Question, queryf.Dollar))
+//	// Output: SELECT * FROM users WHERE id = $1 AND name = $2
+func Rebind(query string, from, to PlaceholderStyle) string {
+	if from == to {
+		return query
+	}
+	names := map[string]int{}
+	return substitute(query, syntax{style: from}, func(p placeholder) (string, bool) {
+		index := p.index
+		if from == Named {
+			if _, ok := names[p.name]; !ok {
+				names[p.name] = len(names) + 1
+			}
+			index = names[p.name]
+		}
+		return placeholderText(to, index), true
+	})
+}
+
+// placeholderText returns the placeholder of the style for the 1-based
+// argument index.
+func placeholderText(style PlaceholderStyle, index int) string {
+	n := strconv.Itoa(index)
+	switch style {
+	case Question:
+		return "?"
+	case Colon:
+		return ":" + n
+	case AtP:
+		return "@p" + n
+	case Named:
+		return ":arg" + n
+	default:
+		return "$" + n
+	}
+}
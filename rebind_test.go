@@ -0,0 +1,22 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestRebind() {
+	query := `SELECT * FROM t WHERE a = ? AND b = '?' AND c = ? -- ?`
+	suite.Equal(`SELECT * FROM t WHERE a = $1 AND b = '?' AND c = $2 -- ?`, Rebind(query, Question, Dollar))
+	suite.Equal(`SELECT * FROM t WHERE a = :1 AND b = '?' AND c = :2 -- ?`, Rebind(query, Question, Colon))
+	suite.Equal(`SELECT * FROM t WHERE a = @p1 AND b = '?' AND c = @p2 -- ?`, Rebind(query, Question, AtP))
+	suite.Equal(`SELECT * FROM t WHERE a = :arg1 AND b = '?' AND c = :arg2 -- ?`, Rebind(query, Question, Named))
+	suite.Equal(query, Rebind(query, Question, Question))
+
+	suite.Equal(`SELECT ?, ?::int, '$1'`, Rebind(`SELECT $2, $1::int, '$1'`, Dollar, Question))
+	suite.Equal(`SELECT $2, $1`, Rebind(`SELECT :2, :1`, Colon, Dollar))
+	suite.Equal(`SELECT $1, $10, @@version`, Rebind(`SELECT @p1, @p10, @@version`, AtP, Dollar))
+	suite.Equal(`SELECT $1, $2, $1, '2022-01-01 10:00'::timestamp`, Rebind(`SELECT :id, @name, :id, '2022-01-01 10:00'::timestamp`, Named, Dollar))
+	suite.Equal(`SELECT ?, ?`, Rebind(`SELECT :id, :name`, Named, Question))
+}
+
+func (suite *QueryfTestSuite) TestPositionalStyles() {
+	suite.Equal(`SELECT 1, 'a', 1`, New(WithPlaceholderStyle(Colon)).Format(`SELECT :1, :2, :1`, 1, "a"))
+	suite.Equal(`SELECT 1, 'a', @p3`, New(WithPlaceholderStyle(AtP)).Format(`SELECT @p1, @p2, @p3`, 1, "a"))
+	suite.Equal(`SELECT 1::int, :name, :1a`, New(WithPlaceholderStyle(Colon)).Format(`SELECT :1::int, :name, :1a`, 1))
+}
@@ -0,0 +1,109 @@
+package queryf
+
+import (
+	"html/template"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RecordedQuery is a query kept by a Recorder.
+type RecordedQuery struct {
+	// Query is the formatted query.
+	Query string
+	// Start is when the query started.
+	Start time.Time
+	// Duration is how long the query took.
+	Duration time.Duration
+	// Err is the error returned by the query, if any.
+	Err error
+}
+
+// Recorder keeps the most recent queries in memory, formatted, and serves them
+// as an HTML page, like net/http/pprof does for profiles. It is safe for
+// concurrent use.
+//
+// Example:
+//
+//	recorder := queryf.NewRecorder(100)
+//	db := queryf.WrapDB(db, queryf.WithRecorder(recorder))
+//	http.Handle("/debug/queries", recorder)
+type Recorder struct {
+	mu      sync.Mutex
+	queries []RecordedQuery
+	next    int
+}
+
+// NewRecorder returns a Recorder that keeps the last size queries. A size of
+// zero or less records nothing.
+func NewRecorder(size int) *Recorder {
+	return &Recorder{queries: make([]RecordedQuery, 0, max(size, 0))}
+}
+
+// WithRecorder records every query run through the SlowDB in r, however long
+// it takes, formatted with the Formatter of the SlowDB.
+func WithRecorder(r *Recorder) DBOption {
+	return func(db *SlowDB) {
+		db.recorder = r
+	}
+}
+
+// Record adds the query, formatted with the package level configuration, to
+// the recorder, replacing the oldest one when it is full.
+func (r *Recorder) Record(query string, args []any, duration time.Duration, err error) {
+	r.record(Default(), query, args, duration, err)
+}
+
+// record is Record, formatting the query with f.
+func (r *Recorder) record(f *Formatter, query string, args []any, duration time.Duration, err error) {
+	q := RecordedQuery{
+		Query:    f.Format(query, args...),
+		Start:    time.Now().Add(-duration),
+		Duration: duration,
+		Err:      err,
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cap(r.queries) == 0 {
+		return
+	}
+	if len(r.queries) < cap(r.queries) {
+		r.queries = append(r.queries, q)
+		return
+	}
+	r.queries[r.next] = q
+	r.next = (r.next + 1) % len(r.queries)
+}
+
+// Queries returns the recorded queries, oldest first.
+func (r *Recorder) Queries() []RecordedQuery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queries := make([]RecordedQuery, 0, len(r.queries))
+	queries = append(queries, r.queries[r.next:]...)
+	return append(queries, r.queries[:r.next]...)
+}
+
+var recorderTemplate = template.Must(template.New("queries").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Recent queries</title></head>
+<body>
+<table>
+<tr><th>Start</th><th>Duration</th><th>Query</th><th>Error</th></tr>
+{{range .}}<tr><td>{{.Start.Format "2006-01-02T15:04:05.000Z07:00"}}</td><td>{{.Duration}}</td><td><pre>{{.Query}}</pre></td><td>{{with .Err}}{{.}}{{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// ServeHTTP renders the recorded queries as an HTML table, newest first.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	queries := r.Queries()
+	for i, j := 0, len(queries)-1; i < j; i, j = i+1, j-1 {
+		queries[i], queries[j] = queries[j], queries[i]
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := recorderTemplate.Execute(w, queries); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
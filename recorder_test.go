@@ -0,0 +1,66 @@
+package queryf
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+func (suite *QueryfTestSuite) TestRecorder() {
+	r := NewRecorder(2)
+	suite.Empty(r.Queries())
+
+	r.Record(`SELECT $1`, []any{1}, time.Millisecond, nil)
+	r.Record(`SELECT $1`, []any{2}, 2*time.Millisecond, nil)
+	r.Record(`SELECT $1`, []any{3}, 3*time.Millisecond, errors.New("boom"))
+	queries := r.Queries()
+	suite.Len(queries, 2)
+	suite.Equal(`SELECT 2`, queries[0].Query)
+	suite.Equal(2*time.Millisecond, queries[0].Duration)
+	suite.Equal(`SELECT 3`, queries[1].Query)
+	suite.EqualError(queries[1].Err, "boom")
+	suite.WithinDuration(time.Now(), queries[1].Start, time.Second)
+
+	r.Record(`SELECT $1`, []any{4}, 0, nil)
+	r.Record(`SELECT $1`, []any{5}, 0, nil)
+	suite.Equal([]string{`SELECT 4`, `SELECT 5`}, []string{r.Queries()[0].Query, r.Queries()[1].Query})
+
+	NewRecorder(0).Record(`SELECT 1`, nil, 0, nil)
+	negative := NewRecorder(-1)
+	negative.Record(`SELECT 1`, nil, 0, nil)
+	suite.Empty(negative.Queries())
+}
+
+func (suite *QueryfTestSuite) TestRecorderHandler() {
+	r := NewRecorder(10)
+	r.Record(`SELECT $1`, []any{"<old>"}, time.Second, nil)
+	r.Record(`SELECT 2`, nil, time.Millisecond, errors.New("boom"))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/debug/queries", nil))
+	suite.Equal(200, w.Code)
+	suite.Equal("text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	suite.Contains(body, `<td>1s</td><td><pre>SELECT &#39;&lt;old&gt;&#39;</pre></td><td></td>`)
+	suite.Contains(body, `<td>1ms</td><td><pre>SELECT 2</pre></td><td>boom</td>`)
+	suite.Less(strings.Index(body, "SELECT 2"), strings.Index(body, "&lt;old&gt;"))
+}
+
+func (suite *QueryfTestSuite) TestWrapDBRecorder() {
+	_, sqlDB := newFakeDB()
+	defer sqlDB.Close()
+	r := NewRecorder(10)
+
+	db := WrapDB(sqlDB, WithRecorder(r), WithSlowThreshold(time.Hour))
+	_, err := db.ExecContext(context.Background(), `DELETE FROM t WHERE id = $1`, 1)
+	suite.Nil(err)
+	suite.Len(r.Queries(), 1)
+	suite.Equal(`DELETE FROM t WHERE id = 1`, r.Queries()[0].Query)
+
+	db = WrapDB(sqlDB, WithRecorder(r), WithFormatter(New(WithRedactedParams(1))), WithSlowThreshold(time.Hour))
+	_, err = db.ExecContext(context.Background(), `DELETE FROM t WHERE name = $1`, "secret")
+	suite.Nil(err)
+	suite.Equal(`DELETE FROM t WHERE name = '[REDACTED]'`, r.Queries()[1].Query)
+}
@@ -0,0 +1,45 @@
+package queryf
+
+// redactedValue replaces the redacted arguments in the formatted query.
+const redactedValue = "[REDACTED]"
+
+// WithRedactedParams redacts the arguments at the given 1-based positions, so
+// WithRedactedParams(2) hides the value of $2. Only positional placeholders
+// can be redacted by position, use WithRedactor for named ones.
+func WithRedactedParams(indices ...int) Option {
+	redacted := make(map[int]bool, len(indices))
+	for _, index := range indices {
+		redacted[index] = true
+	}
+	return WithRedactor(func(index int, _ any) bool {
+		return redacted[index]
+	})
+}
+
+// WithRedactor redacts every argument for which redact returns true. index is
+// the 1-based position of the argument, or 0 for named arguments. Redactors
+// are combined, an argument is redacted if any of them returns true.
+//
+// Example:
+//
+//	f := queryf.New(queryf.WithRedactor(func(_ int, arg any) bool {
+//		_, ok := arg.(Password)
+//		return ok
+//	}))
+func WithRedactor(redact func(index int, arg any) bool) Option {
+	return func(f *Formatter) {
+		f.redactors = append(f.redactors, redact)
+	}
+}
+
+func (f *Formatter) redacted(index int, arg any) bool {
+	if f.locked() {
+		return true
+	}
+	for _, redact := range f.redactors {
+		if redact(index, arg) {
+			return true
+		}
+	}
+	return false
+}
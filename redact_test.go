@@ -0,0 +1,32 @@
+package queryf
+
+type password string
+
+func (suite *QueryfTestSuite) TestRedactedParams() {
+	f := New(WithRedactedParams(2, 3))
+	suite.Equal(`UPDATE users SET password = '[REDACTED]', token = '[REDACTED]' WHERE id = 1`,
+		f.Format(`UPDATE users SET password = $2, token = $3 WHERE id = $1`, 1, "secret", "abc"))
+	suite.Equal(`SELECT 1`, f.Format(`SELECT $1`, 1))
+}
+
+func (suite *QueryfTestSuite) TestRedactor() {
+	f := New(
+		WithRedactor(func(_ int, arg any) bool {
+			_, ok := arg.(password)
+			return ok
+		}),
+		WithRedactedParams(1),
+	)
+	suite.Equal(`SELECT '[REDACTED]', '[REDACTED]', 'John'`, f.Format(`SELECT $1, $2, $3`, 1, password("x"), "John"))
+	suite.Equal(`SELECT '[REDACTED]', 'John'`,
+		f.FormatNamed(`SELECT :password, :name`, map[string]any{"password": password("x"), "name": "John"}))
+}
+
+func (suite *QueryfTestSuite) TestRedactorArgument() {
+	f := New(WithRedactor(func(_ int, arg any) bool {
+		_, ok := arg.(password)
+		return ok
+	}))
+	suite.Equal(`'[REDACTED]'`, f.NewArgument(password("x")).Format())
+	suite.Equal(`'John'`, f.NewArgument("John").Format())
+}
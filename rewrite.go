@@ -0,0 +1,65 @@
+package queryf
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// pgxPkgPath is the package of pgx, whose QueryRewriter interface is detected
+// without importing it.
+const pgxPkgPath = "github.com/jackc/pgx/v5"
+
+// rewriterTypes caches whether the types of first arguments implement
+// pgx.QueryRewriter.
+var rewriterTypes sync.Map // map[reflect.Type]bool
+
+var (
+	contextType  = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+	anySliceType = reflect.TypeOf([]any(nil))
+)
+
+// rewriteQuery returns the query and the arguments pgx sends to the server:
+// when the first argument implements pgx.QueryRewriter, e.g. pgx.NamedArgs,
+// pgx replaces the query and the other arguments by the result of its
+// RewriteQuery method. The query is returned as is if the rewrite fails.
+func rewriteQuery(query string, args []any) (string, []any) {
+	if len(args) == 0 || args[0] == nil {
+		return query, args
+	}
+	t := reflect.TypeOf(args[0])
+	if t.NumMethod() == 0 || !isQueryRewriter(t) {
+		return query, args
+	}
+	method := reflect.ValueOf(args[0]).MethodByName("RewriteQuery")
+	conn := method.Type().In(1)
+	out := method.Call([]reflect.Value{
+		reflect.ValueOf(context.Background()),
+		reflect.Zero(conn),
+		reflect.ValueOf(query),
+		reflect.ValueOf(args[1:]),
+	})
+	if !out[2].IsNil() {
+		return query, args
+	}
+	return out[0].String(), out[1].Interface().([]any)
+}
+
+// isQueryRewriter reports whether t has the method of pgx.QueryRewriter:
+//
+//	RewriteQuery(ctx context.Context, conn *pgx.Conn, sql string, args []any) (newSQL string, newArgs []any, err error)
+func isQueryRewriter(t reflect.Type) bool {
+	if is, ok := rewriterTypes.Load(t); ok {
+		return is.(bool)
+	}
+	m, ok := t.MethodByName("RewriteQuery")
+	// The method type of a reflect.Type has the receiver as first input.
+	is := ok && m.Type.NumIn() == 5 && m.Type.NumOut() == 3 &&
+		m.Type.In(1) == contextType &&
+		m.Type.In(2).Kind() == reflect.Ptr && isNamedType(m.Type.In(2).Elem(), pgxPkgPath, "Conn") &&
+		m.Type.In(3).Kind() == reflect.String && m.Type.In(4) == anySliceType &&
+		m.Type.Out(0).Kind() == reflect.String && m.Type.Out(1) == anySliceType && m.Type.Out(2) == errorType
+	rewriterTypes.Store(t, is)
+	return is
+}
@@ -0,0 +1,42 @@
+package queryf
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// failingRewriter is a pgx.QueryRewriter whose rewrite fails.
+type failingRewriter struct{}
+
+func (failingRewriter) RewriteQuery(context.Context, *pgx.Conn, string, []any) (string, []any, error) {
+	return "", nil, errors.New("boom")
+}
+
+func (suite *QueryfTestSuite) TestFormatNamedArgs() {
+	args := pgx.NamedArgs{"id": 1, "name": "John"}
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND name = 'John' AND id = 1`,
+		Format(`SELECT * FROM users WHERE id = @id AND name = @name AND id = @id`, args))
+	suite.Equal(`SELECT 'x'`, Format(`SELECT @v`, pgx.StrictNamedArgs{"v": "x"}))
+	suite.Equal(`SELECT @v`, Format(`SELECT @v`, failingRewriter{}))
+	suite.Equal(`SELECT 1`, Format(`SELECT $1`, 1))
+
+	err := &pgconn.PgError{Severity: "ERROR", Code: "42703", Message: `column "nme" does not exist`, Position: 8}
+	suite.Equal("ERROR: column \"nme\" does not exist (SQLSTATE 42703)\nSELECT nme FROM users WHERE id = 1\n       ^",
+		FormatWithError(`SELECT nme FROM users WHERE id = @id`, []any{args}, err))
+}
+
+func (suite *QueryfTestSuite) TestStrictNamedArgs() {
+	args := pgx.NamedArgs{"id": 1}
+	query, err := FormatE(`SELECT * FROM users WHERE id = @id`, args)
+	suite.Nil(err)
+	suite.Equal(`SELECT * FROM users WHERE id = 1`, query)
+	_, err = FormatE(`SELECT * FROM users WHERE id = @id AND name = @name`, pgx.StrictNamedArgs{"id": 1})
+	suite.ErrorIs(err, ErrArgumentCount)
+
+	suite.Nil(Validate(`SELECT * FROM users WHERE id = @id`, args))
+	suite.Equal(`SELECT * FROM users WHERE id = 1`, Compile(`SELECT * FROM users WHERE id = @id`).Format(args))
+	suite.Equal(`SELECT <span class="queryf-arg">1</span>`, string(FormatHTML(`SELECT @id`, args)))
+}
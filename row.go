@@ -0,0 +1,101 @@
+package queryf
+
+import (
+	"reflect"
+	"strings"
+)
+
+// RowValue is an argument formatted as a composite row literal. Use Row to
+// create one.
+type RowValue struct {
+	value any
+	typ   string
+}
+
+// Row wraps a struct, or a slice or array of fields, so it is formatted as a
+// composite row literal instead of JSON. Only the exported fields of a struct
+// are used, in declaration order.
+//
+// Example:
+//
+//	fmt.Println(queryf.Format("INSERT INTO t (c) VALUES ($1)", queryf.Row([]any{"a", 1, true})))
+//	// Output: INSERT INTO t (c) VALUES (ROW('a', 1, true))
+func Row(v any) RowValue {
+	return RowValue{value: v}
+}
+
+// As returns the row cast to the composite type typ, e.g. ROW('a', 1)::mytype.
+func (r RowValue) As(typ string) RowValue {
+	r.typ = typ
+	return r
+}
+
+func (a *Argument) isRow() bool {
+	_, ok := a.arg.(RowValue)
+	return ok
+}
+
+// formatRow returns the ROW(...) literal of the wrapped value.
+func (a *Argument) formatRow() string {
+	r := a.arg.(RowValue)
+	v := reflect.ValueOf(r.value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return a.formatNull()
+		}
+		v = v.Elem()
+	}
+
+	var fields []string
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fields = append(fields, a.nested(v.Field(i).Interface()).Format())
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return a.formatNull()
+		}
+		for i := 0; i < v.Len(); i++ {
+			fields = append(fields, a.nested(v.Index(i).Interface()).Format())
+		}
+	case reflect.Invalid:
+		return a.formatNull()
+	default:
+		fields = append(fields, a.nested(v.Interface()).Format())
+	}
+	if a.formatter.copyText {
+		return compositeText(fields, a.formatter.nullLiteral)
+	}
+	literal := "ROW(" + strings.Join(fields, ", ") + ")"
+	if r.typ != "" {
+		return a.formatter.cast(literal, r.typ)
+	}
+	return literal
+}
+
+// compositeEscaper escapes the quoted fields of the composite text form.
+var compositeEscaper = strings.NewReplacer(`"`, `""`, `\`, `\\`)
+
+// compositeText returns the literals of the fields in the composite text form
+// read by COPY, e.g. (a,1,t): NULL fields are empty, and fields that are empty
+// or have separators, quotes or spaces are double quoted.
+func compositeText(fields []string, null string) string {
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		if field == null {
+			continue
+		}
+		v := field
+		if s, ok := unquote(uncast(field)); ok {
+			v = s
+		}
+		if v == "" || strings.ContainsAny(v, "(),\"\\ \t\n\r") {
+			v = `"` + compositeEscaper.Replace(v) + `"`
+		}
+		values[i] = v
+	}
+	return "(" + strings.Join(values, ",") + ")"
+}
@@ -0,0 +1,24 @@
+package queryf
+
+type item struct {
+	Name     string
+	Quantity int
+	Price    *float64
+	internal bool
+	Active   bool
+}
+
+func (suite *QueryfTestSuite) TestRow() {
+	suite.Equal(`SELECT ROW('a', 1, true)`, Format(`SELECT $1`, Row([]any{"a", 1, true})))
+	suite.Equal(`SELECT ROW('it''s', 2, NULL, false)`, Format(`SELECT $1`, Row(item{Name: "it's", Quantity: 2})))
+	suite.Equal(`SELECT ROW('a', 1, NULL, true)::item`, Format(`SELECT $1`, Row(&item{Name: "a", Quantity: 1, Active: true}).As("item")))
+	suite.Equal(`SELECT ROW(ROW(1, 2), '{"a","b"}')`, Format(`SELECT $1`, Row([2]any{Row([]int{1, 2}), []string{"a", "b"}})))
+	suite.Equal(`SELECT ROW()`, Format(`SELECT $1`, Row([]any{})))
+	suite.Equal(`SELECT ROW(1)`, Format(`SELECT $1`, Row(1)))
+
+	var p *item
+	suite.Equal(`SELECT NULL`, Format(`SELECT $1`, Row(p)))
+	suite.Equal(`SELECT NULL`, Format(`SELECT $1`, Row(nil)))
+	suite.Equal(`SELECT ROW('a', 1)`, New(WithDialect(MySQL)).Format(`SELECT ?`, Row([]any{"a", 1}).As("item")))
+	suite.Equal(Composite, NewArgument(Row([]any{1})).GetType())
+}
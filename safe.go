@@ -0,0 +1,31 @@
+package queryf
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPanic is returned by FuzzSafeFormat when formatting the query panicked.
+var ErrPanic = errors.New("queryf: formatting panicked")
+
+// FuzzSafeFormat works like Format but never panics, whatever the query and
+// the arguments: a panic while formatting is returned as an ErrPanic error. It
+// is meant for fuzz targets and for callers that format untrusted input.
+//
+// Example:
+//
+//	query, err := queryf.FuzzSafeFormat(input, args...)
+func FuzzSafeFormat(query string, args ...any) (string, error) {
+	return Default().FuzzSafeFormat(query, args...)
+}
+
+// FuzzSafeFormat works like Format but never panics. See the package level
+// FuzzSafeFormat for details.
+func (f *Formatter) FuzzSafeFormat(query string, args ...any) (formatted string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			formatted, err = "", fmt.Errorf("%w: %v", ErrPanic, r)
+		}
+	}()
+	return f.Format(query, args...), nil
+}
@@ -0,0 +1,202 @@
+package queryf
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// placeholder is a placeholder found in a query.
+type placeholder struct {
+	// start and end are the byte offsets of the placeholder in the query.
+	start, end int
+	// index is the 1-based argument position the placeholder refers to.
+	// Named placeholders have index 0.
+	index int
+	name  string
+	// escaped is set for the escaped ?? of the Question style, which are
+	// replaced by ?.
+	escaped bool
+}
+
+// scanner finds the placeholders of a query in a single pass, skipping
+// string literals, quoted identifiers, comments and dollar-quoted bodies.
+type scanner struct {
+	tokenizer *tokenizer
+}
+
+func newScanner(query string, s syntax) *scanner {
+	return &scanner{tokenizer: newTokenizer(query, s)}
+}
+
+// next returns the next placeholder of the query, or false when there are no
+// more placeholders.
+func (s *scanner) next() (placeholder, bool) {
+	for tok, ok := s.tokenizer.next(); ok; tok, ok = s.tokenizer.next() {
+		if tok.kind == tokenPlaceholder {
+			return tok.placeholder, true
+		}
+	}
+	return placeholder{}, false
+}
+
+// scanPlaceholders returns the placeholders of the query, in order, along with
+// its escaped ?? as escaped placeholders.
+func scanPlaceholders(query string, s syntax) []placeholder {
+	var placeholders []placeholder
+	t := newTokenizer(query, s)
+	for tok, ok := t.next(); ok; tok, ok = t.next() {
+		switch {
+		case tok.kind == tokenPlaceholder:
+			placeholders = append(placeholders, tok.placeholder)
+		case tok.escaped:
+			placeholders = append(placeholders, placeholder{start: tok.start, end: tok.end, escaped: true})
+		}
+	}
+	return placeholders
+}
+
+// count returns the number of positional placeholders found so far.
+func (s *scanner) count() int {
+	return s.tokenizer.count
+}
+
+func (t *tokenizer) placeholderAt(i int) (placeholder, bool) {
+	switch t.style {
+	case Question:
+		if t.query[i] != '?' {
+			return placeholder{}, false
+		}
+		t.count++
+		return placeholder{start: i, end: i + 1, index: t.count}, true
+	case Named:
+		if !isNamedPrefix(t.query, i) {
+			return placeholder{}, false
+		}
+		end := namedEnd(t.query, i+1)
+		return placeholder{start: i, end: end, name: t.query[i+1 : end]}, true
+	case Colon:
+		if t.query[i] != ':' || (i > 0 && t.query[i-1] == ':') {
+			return placeholder{}, false
+		}
+		return t.numberedAt(i, i+1)
+	case AtP:
+		if !strings.HasPrefix(t.query[i:], "@p") || (i > 0 && t.query[i-1] == '@') {
+			return placeholder{}, false
+		}
+		return t.numberedAt(i, i+2)
+	default:
+		if t.query[i] != '$' {
+			return placeholder{}, false
+		}
+		return t.numberedAt(i, i+1)
+	}
+}
+
+// numberedAt returns the numbered placeholder starting at position i whose
+// number starts at position digits.
+func (t *tokenizer) numberedAt(i, digits int) (placeholder, bool) {
+	end := t.scanWhile(digits, isDigit)
+	if end == digits || (end < len(t.query) && isNameChar(t.query[end])) {
+		return placeholder{}, false
+	}
+	index, err := strconv.Atoi(t.query[digits:end])
+	if err != nil {
+		return placeholder{}, false
+	}
+	return placeholder{start: i, end: end, index: index}, true
+}
+
+func (t *tokenizer) scanWhile(i int, fn func(c byte) bool) int {
+	for i < len(t.query) && fn(t.query[i]) {
+		i++
+	}
+	return i
+}
+
+// placeholderPrefixes returns the characters that can start a placeholder of
+// the style.
+func placeholderPrefixes(style PlaceholderStyle) string {
+	switch style {
+	case Question:
+		return "?"
+	case Named:
+		return ":@"
+	case Colon:
+		return ":"
+	case AtP:
+		return "@"
+	default:
+		return "$"
+	}
+}
+
+// bufferPool holds the buffers substitute writes the queries to, so they are
+// only allocated once they grow, and not for every query.
+var bufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 1024)
+		return &b
+	},
+}
+
+// maxPooledBuffer is the capacity above which buffers are dropped instead of
+// returned to the pool, so a single huge query doesn't stay in memory.
+const maxPooledBuffer = 64 << 10
+
+// substitute returns the query with every placeholder for which value returns
+// true replaced by the returned string, and the escaped ?? of the Question
+// style replaced by ?. Queries without any character that could start a
+// placeholder are returned as is, without scanning them.
+func substitute(query string, s syntax, value func(p placeholder) (string, bool)) string {
+	return replacePlaceholders(query, s, true, value)
+}
+
+// replacePlaceholders is substitute, leaving the escaped ?? as is unless
+// unescape is true.
+func replacePlaceholders(query string, s syntax, unescape bool, value func(p placeholder) (string, bool)) string {
+	if !strings.ContainsAny(query, placeholderPrefixes(s.style)) {
+		return query
+	}
+	buf := bufferPool.Get().(*[]byte)
+	b := (*buf)[:0]
+	t := newTokenizer(query, s)
+	last := 0
+	for tok, ok := t.next(); ok; tok, ok = t.next() {
+		var v string
+		switch {
+		case tok.kind == tokenPlaceholder:
+			if v, ok = value(tok.placeholder); !ok {
+				continue
+			}
+		case tok.escaped && unescape:
+			v = "?"
+		default:
+			continue
+		}
+		b = append(b, query[last:tok.start]...)
+		b = append(b, v...)
+		last = tok.end
+	}
+	if last == 0 {
+		bufferPool.Put(buf)
+		return query
+	}
+	b = append(b, query[last:]...)
+	return releaseBuffer(buf, b)
+}
+
+// releaseBuffer returns b, the contents of the pooled buffer buf, as a string,
+// and puts buf back in the pool unless it grew too large.
+func releaseBuffer(buf *[]byte, b []byte) string {
+	s := string(b)
+	if cap(b) <= maxPooledBuffer {
+		*buf = b
+		bufferPool.Put(buf)
+	}
+	return s
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
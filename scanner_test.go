@@ -0,0 +1,117 @@
+package queryf
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func (suite *QueryfTestSuite) TestScannerBoundaries() {
+	suite.Equal(`SELECT 1, $1a, 1$`, Format(`SELECT $1, $1a, $1$`, 1))
+	suite.Equal(`SELECT 10, 1`, Format(`SELECT $10, $1`, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10))
+	suite.Equal(`SELECT $2`, Format(`SELECT $2`, 1))
+	suite.Equal(`SELECT $99999999999999999999`, Format(`SELECT $99999999999999999999`, 1))
+}
+
+func (suite *QueryfTestSuite) TestScannerEscapedQuestion() {
+	f := New(WithPlaceholderStyle(Question))
+	suite.Equal(`SELECT data ? 'a', data ?| array['b'], 1 FROM t WHERE x = '?' -- ?`,
+		f.Format(`SELECT data ?? 'a', data ??| array['b'], ? FROM t WHERE x = '?' -- ?`, 1))
+	suite.Equal(`SELECT ?1`, f.Format(`SELECT ???`, 1))
+	suite.Nil(f.Validate(`SELECT data ??& array['a'] AND id = ?`, 1))
+
+	query, args, err := f.Expand(`SELECT * FROM t WHERE data ?? 'a' AND id IN (?)`, []int{1, 2})
+	suite.Nil(err)
+	suite.Equal(`SELECT * FROM t WHERE data ?? 'a' AND id IN (?, ?)`, query)
+	suite.Equal([]any{1, 2}, args)
+
+	suite.Equal(`SELECT data ? 'a', $1`, Rebind(`SELECT data ?? 'a', ?`, Question, Dollar))
+	suite.Equal(`SELECT ??, 1`, Format(`SELECT ??, $1`, 1))
+}
+
+func (suite *QueryfTestSuite) TestScannerBackslashStrings() {
+	mysql := New(WithDialect(MySQL))
+	suite.Equal(`SELECT 'it\'s ?', 1`, mysql.Format(`SELECT 'it\'s ?', ?`, 1))
+	suite.Equal(`SELECT 'a\\', 1, 'it''s ?'`, mysql.Format(`SELECT 'a\\', ?, 'it''s ?'`, 1))
+	suite.Equal(`SELECT 'it\'s ?', 1`, mysql.Compile(`SELECT 'it\'s ?', ?`).Format(1))
+	query, err := mysql.FormatE(`SELECT 'it\'s ?', ?`, `it's`)
+	suite.Nil(err)
+	suite.Equal(`SELECT 'it\'s ?', 'it''s'`, query)
+
+	// Standard strings end at the first lone quote.
+	suite.Equal(`SELECT 'a\', 1`, Format(`SELECT 'a\', $1`, 1))
+}
+
+// formatRegexp is the previous implementation of Format, compiling a regexp
+// per argument. It is kept to compare against in the benchmarks.
+func formatRegexp(query string, args ...any) string {
+	queryb := []byte(query)
+	for i, arg := range args {
+		re := regexp.MustCompile(fmt.Sprintf(`\$%d\b`, i+1))
+		queryb = re.ReplaceAll(queryb, []byte(NewArgument(arg).Format()))
+	}
+	return string(queryb)
+}
+
+func benchmarkQuery(n int) (string, []any) {
+	columns := make([]string, n)
+	args := make([]any, n)
+	for i := range columns {
+		columns[i] = fmt.Sprintf("col%d = $%d", i, i+1)
+		args[i] = i
+	}
+	return "SELECT * FROM t WHERE " + strings.Join(columns, " AND "), args
+}
+
+func BenchmarkFormat(b *testing.B) {
+	for _, n := range []int{1, 10, 50} {
+		query, args := benchmarkQuery(n)
+		b.Run(fmt.Sprintf("scanner/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				Format(query, args...)
+			}
+		})
+		b.Run(fmt.Sprintf("regexp/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				formatRegexp(query, args...)
+			}
+		})
+	}
+}
+
+func BenchmarkFormatTypes(b *testing.B) {
+	t := time.Date(2022, 2, 10, 10, 0, 0, 0, time.UTC)
+	query := `SELECT * FROM users WHERE name = $1 AND born_at > $2 AND deleted_at IS $3 AND active = $4 AND score > $5 AND id = ANY($6)`
+	args := []any{"John", t, nil, true, 1.5, []int{1, 2, 3}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Format(query, args...)
+	}
+}
+
+func BenchmarkFormatNoPlaceholders(b *testing.B) {
+	query := `SELECT * FROM users WHERE active AND deleted_at IS NULL ORDER BY name`
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Format(query)
+	}
+}
+
+func (suite *QueryfTestSuite) TestFormatAllocations() {
+	// Only the formatted query is allocated for integer arguments.
+	query, args := benchmarkQuery(10)
+	allocs := testing.AllocsPerRun(100, func() { Format(query, args...) })
+	suite.Equal(1.0, allocs)
+	allocs = testing.AllocsPerRun(100, func() { Format(`SELECT * FROM users`) })
+	suite.Zero(allocs)
+}
+
+func (suite *QueryfTestSuite) TestSubstituteLargeQuery() {
+	query := `SELECT $1, '` + strings.Repeat("x", 2*maxPooledBuffer) + `'`
+	suite.Equal(`SELECT 1, '`+strings.Repeat("x", 2*maxPooledBuffer)+`'`, Format(query, 1))
+	suite.Equal(`SELECT 2`, Format(`SELECT $1`, 2))
+}
@@ -0,0 +1,28 @@
+package queryf
+
+import "log/slog"
+
+// Query is a query and its arguments. It implements slog.LogValuer, so the
+// query is only formatted if the log record is actually emitted.
+//
+// Example:
+//
+//	logger.Debug("running query", "query", queryf.Query{SQL: query, Args: args})
+type Query struct {
+	SQL  string
+	Args []any
+}
+
+// LogValue returns the formatted query.
+func (q Query) LogValue() slog.Value {
+	return slog.StringValue(Format(q.SQL, q.Args...))
+}
+
+// Attr returns a "query" attribute that formats the query only when logged.
+//
+// Example:
+//
+//	logger.Debug("running query", queryf.Attr(query, args...))
+func Attr(query string, args ...any) slog.Attr {
+	return slog.Any("query", Query{SQL: query, Args: args})
+}
@@ -0,0 +1,26 @@
+package queryf
+
+import (
+	"bytes"
+	"log/slog"
+)
+
+func (suite *QueryfTestSuite) TestSlog() {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("running", Attr(`SELECT $1`, "John"))
+	suite.Equal("level=INFO msg=running query=\"SELECT 'John'\"\n", buf.String())
+}
+
+func (suite *QueryfTestSuite) TestSlogIsLazy() {
+	attr := Attr(`SELECT $1`, 1)
+	suite.Equal(slog.KindLogValuer, attr.Value.Kind())
+	suite.Equal(`SELECT 1`, attr.Value.Resolve().String())
+}
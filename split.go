@@ -0,0 +1,60 @@
+package queryf
+
+import "strings"
+
+// SplitStatements splits a query holding several statements at the semicolons
+// that separate them, ignoring those inside literals, quoted identifiers,
+// comments and dollar-quoted bodies. The statements are trimmed and don't
+// include the semicolon. Statements made only of comments are dropped.
+//
+// Format binds the placeholders of the whole query, so the arguments of
+// "SELECT $1; SELECT $2" or "SELECT ?; SELECT ?" are shared by all of its
+// statements. Format the query first to split it with its arguments.
+//
+// Example:
+//
+//	query := queryf.Format("UPDATE users SET name = $1 WHERE id = $2; SELECT * FROM users WHERE id = $2", "a;b", 1)
+//	for _, stmt := range queryf.SplitStatements(query) {
+//		fmt.Println(stmt)
+//	}
+//	// Output:
+//	// UPDATE users SET name = 'a;b' WHERE id = 1
+//	// SELECT * FROM users WHERE id = 1
+func SplitStatements(query string) []string {
+	return Default().SplitStatements(query)
+}
+
+// SplitStatements splits a query holding several statements, using the
+// Formatter placeholder style. See the package level SplitStatements for
+// details.
+func (f *Formatter) SplitStatements(query string) []string {
+	var stmts []string
+	start, empty := 0, true
+	add := func(end int) {
+		if !empty {
+			stmts = append(stmts, strings.TrimSpace(query[start:end]))
+		}
+		start, empty = end+1, true
+	}
+	t := newTokenizer(query, f.syntax())
+	for tok, ok := t.next(); ok; tok, ok = t.next() {
+		if tok.kind == tokenComment {
+			continue
+		}
+		if tok.kind != tokenText {
+			empty = false
+			continue
+		}
+		text := query[tok.start:tok.end]
+		for i := 0; i < len(text); i++ {
+			switch c := text[i]; {
+			case c == ';':
+				add(tok.start + i)
+			case c != ' ' && c != '\t' && c != '\n' && c != '\r':
+				empty = false
+			}
+		}
+	}
+	add(len(query))
+	return stmts
+}
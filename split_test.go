@@ -0,0 +1,41 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestSplitStatements() {
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{query: `SELECT 1`, expected: []string{`SELECT 1`}},
+		{query: "SELECT 1;\nSELECT 2;\n", expected: []string{`SELECT 1`, `SELECT 2`}},
+		{query: `SELECT 'a;b'; SELECT "c;d"`, expected: []string{`SELECT 'a;b'`, `SELECT "c;d"`}},
+		{query: "SELECT 1 -- one; two\n; SELECT /* ; */ 2", expected: []string{"SELECT 1 -- one; two", `SELECT /* ; */ 2`}},
+		{
+			query: `CREATE FUNCTION one() RETURNS int AS $$ BEGIN RETURN 1; END $$ LANGUAGE plpgsql; SELECT one()`,
+			expected: []string{
+				`CREATE FUNCTION one() RETURNS int AS $$ BEGIN RETURN 1; END $$ LANGUAGE plpgsql`,
+				`SELECT one()`,
+			},
+		},
+		{query: "SELECT 1;; ; -- done\n", expected: []string{`SELECT 1`}},
+		{query: `; `, expected: nil},
+		{query: `SELECT 'unterminated; SELECT 2`, expected: []string{`SELECT 'unterminated; SELECT 2`}},
+	}
+	for _, test := range tests {
+		suite.Equal(test.expected, SplitStatements(test.query), test.query)
+	}
+
+	f := New(WithPlaceholderStyle(Question))
+	suite.Equal([]string{`SELECT ?`, `SELECT ?`}, f.SplitStatements(`SELECT ?; SELECT ?`))
+}
+
+func (suite *QueryfTestSuite) TestFormatMultipleStatements() {
+	query := Format(`UPDATE users SET name = $1 WHERE id = $2; SELECT * FROM users WHERE id = $2`, "a;b", 1)
+	suite.Equal(`UPDATE users SET name = 'a;b' WHERE id = 1; SELECT * FROM users WHERE id = 1`, query)
+	suite.Equal([]string{
+		`UPDATE users SET name = 'a;b' WHERE id = 1`,
+		`SELECT * FROM users WHERE id = 1`,
+	}, SplitStatements(query))
+
+	f := New(WithPlaceholderStyle(Question))
+	suite.Equal(`SELECT 1; SELECT 'John'`, f.Format(`SELECT ?; SELECT ?`, 1, "John"))
+}
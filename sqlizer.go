@@ -0,0 +1,30 @@
+package queryf
+
+// Sqlizer is implemented by query builders such as squirrel, whose ToSql
+// returns the built query and its arguments.
+type Sqlizer interface {
+	ToSql() (string, []any, error)
+}
+
+// FormatSqlizer builds the query with ToSql and formats it, returning the
+// builder error if any.
+//
+// Example:
+//
+//	users := sq.Select("*").From("users").Where(sq.Eq{"id": 1}).PlaceholderFormat(sq.Dollar)
+//	query, err := queryf.FormatSqlizer(users)
+//	fmt.Println(query)
+//	// Output: SELECT * FROM users WHERE id = 1
+func FormatSqlizer(s Sqlizer) (string, error) {
+	return Default().FormatSqlizer(s)
+}
+
+// FormatSqlizer builds the query with ToSql and formats it with the Formatter
+// configuration. See the package level FormatSqlizer for details.
+func (f *Formatter) FormatSqlizer(s Sqlizer) (string, error) {
+	query, args, err := s.ToSql()
+	if err != nil {
+		return "", err
+	}
+	return f.Format(query, args...), nil
+}
@@ -0,0 +1,28 @@
+package queryf
+
+import "errors"
+
+type fakeSqlizer struct {
+	query string
+	args  []any
+	err   error
+}
+
+func (s fakeSqlizer) ToSql() (string, []any, error) {
+	return s.query, s.args, s.err
+}
+
+func (suite *QueryfTestSuite) TestFormatSqlizer() {
+	query, err := FormatSqlizer(fakeSqlizer{query: `SELECT * FROM users WHERE id = $1 AND name = $2`, args: []any{1, "John"}})
+	suite.NoError(err)
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND name = 'John'`, query)
+
+	f := New(WithPlaceholderStyle(Question))
+	query, err = f.FormatSqlizer(fakeSqlizer{query: `SELECT * FROM users WHERE id = ?`, args: []any{nil}})
+	suite.NoError(err)
+	suite.Equal(`SELECT * FROM users WHERE id = NULL`, query)
+
+	failure := errors.New("select statements must have at least one result column")
+	_, err = FormatSqlizer(fakeSqlizer{err: failure})
+	suite.ErrorIs(err, failure)
+}
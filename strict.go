@@ -0,0 +1,41 @@
+package queryf
+
+import (
+	"errors"
+	"os"
+	"strconv"
+)
+
+// UnlockEnv is the environment variable that unlocks the Formatters created
+// with WithStrictSafety, e.g. QUERYF_UNLOCK=true.
+const UnlockEnv = "QUERYF_UNLOCK"
+
+// ErrLocked is returned by FormatE when the Formatter is locked by
+// WithStrictSafety.
+var ErrLocked = errors.New("queryf: formatting is locked, set " + UnlockEnv + "=true to unlock it")
+
+// WithStrictSafety locks the Formatter unless the QUERYF_UNLOCK environment
+// variable is true: while locked, every argument is redacted and FormatE
+// returns ErrLocked. It guards against interpolated queries reaching
+// production code paths, while developers can still unlock them locally. The
+// variable is read on every call.
+//
+// Example:
+//
+//	f := queryf.New(queryf.WithStrictSafety())
+//	fmt.Println(f.Format("SELECT * FROM users WHERE id = $1", 1))
+//	// Output: SELECT * FROM users WHERE id = '[REDACTED]'
+func WithStrictSafety() Option {
+	return func(f *Formatter) {
+		f.strictSafety = true
+	}
+}
+
+// locked reports whether the Formatter is locked by WithStrictSafety.
+func (f *Formatter) locked() bool {
+	if !f.strictSafety {
+		return false
+	}
+	unlocked, _ := strconv.ParseBool(os.Getenv(UnlockEnv))
+	return !unlocked
+}
@@ -0,0 +1,26 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestStrictSafety() {
+	f := New(WithStrictSafety())
+	suite.T().Setenv(UnlockEnv, "")
+	suite.Equal(`SELECT * FROM users WHERE id = '[REDACTED]' AND name = '[REDACTED]'`,
+		f.Format(`SELECT * FROM users WHERE id = $1 AND name = $2`, 1, "John"))
+	suite.Equal([]string{`'[REDACTED]'`}, f.FormatArgs(nil))
+	_, err := f.FormatE(`SELECT $1`, 1)
+	suite.ErrorIs(err, ErrLocked)
+	suite.Equal(`'[REDACTED]'`, f.NewArgument("John").Format())
+
+	suite.T().Setenv(UnlockEnv, "no")
+	suite.Equal(`SELECT '[REDACTED]'`, f.Format(`SELECT $1`, 1))
+
+	suite.T().Setenv(UnlockEnv, "true")
+	suite.Equal(`SELECT * FROM users WHERE id = 1 AND name = 'John'`,
+		f.Format(`SELECT * FROM users WHERE id = $1 AND name = $2`, 1, "John"))
+	query, err := f.FormatE(`SELECT $1`, 1)
+	suite.NoError(err)
+	suite.Equal(`SELECT 1`, query)
+	suite.Equal(`'John'`, f.NewArgument("John").Format())
+
+	suite.T().Setenv(UnlockEnv, "")
+	suite.Equal(`SELECT 1`, Format(`SELECT $1`, 1))
+}
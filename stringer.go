@@ -0,0 +1,39 @@
+package queryf
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// WithStringerFallback formats arguments implementing encoding.TextMarshaler
+// or fmt.Stringer, such as enums, ULIDs and money types, as the string they
+// return, instead of as their underlying struct, slice or number. Types with a
+// dedicated literal, like time.Time, UUIDs and driver.Valuer implementations,
+// are not affected. TextMarshaler is preferred when both are implemented.
+func WithStringerFallback() Option {
+	return func(f *Formatter) {
+		f.stringerFallback = true
+	}
+}
+
+func (a *Argument) isStringer() bool {
+	if !a.formatter.stringerFallback {
+		return false
+	}
+	switch a.arg.(type) {
+	case encoding.TextMarshaler, fmt.Stringer:
+		return true
+	}
+	return false
+}
+
+func (a *Argument) formatStringer() string {
+	if m, ok := a.arg.(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return a.formatter.quote(fmt.Sprintf("<invalid value: %v>", err))
+		}
+		return a.formatString(string(text))
+	}
+	return a.formatString(a.arg.(fmt.Stringer).String())
+}
@@ -0,0 +1,49 @@
+package queryf
+
+import (
+	"errors"
+	"time"
+)
+
+type status int
+
+func (s status) String() string {
+	return [...]string{"pending", "active"}[s]
+}
+
+type money struct {
+	Cents    int64
+	Currency string
+}
+
+func (m money) String() string {
+	return "$1.50"
+}
+
+type ulid struct {
+	id string
+}
+
+func (u ulid) MarshalText() ([]byte, error) {
+	if u.id == "" {
+		return nil, errors.New("empty ulid")
+	}
+	return []byte(u.id), nil
+}
+
+func (u ulid) String() string {
+	return "ulid:" + u.id
+}
+
+func (suite *QueryfTestSuite) TestWithStringerFallback() {
+	suite.Equal(`SELECT 1, '{"Cents":150,"Currency":"USD"}'`, Format(`SELECT $1, $2`, status(1), money{150, "USD"}))
+
+	f := New(WithStringerFallback())
+	suite.Equal(`SELECT 'active', '$1.50'`, f.Format(`SELECT $1, $2`, status(1), money{150, "USD"}))
+	suite.Equal(`SELECT '01ARZ3NDEKTSV4RRFFQ69G5FAV'`, f.Format(`SELECT $1`, ulid{"01ARZ3NDEKTSV4RRFFQ69G5FAV"}))
+	suite.Equal(`SELECT '<invalid value: empty ulid>'`, f.Format(`SELECT $1`, ulid{}))
+	suite.Equal(`SELECT '{"pending","active"}'`, f.Format(`SELECT $1`, []status{0, 1}))
+	suite.Equal(`SELECT 'active'`, f.Format(`SELECT $1`, &[]status{1}[0]))
+	suite.Equal(`SELECT '2022-02-10T00:00:00Z'`, f.Format(`SELECT $1`, time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)))
+	suite.Equal(String, f.NewArgument(status(1)).GetType())
+}
@@ -0,0 +1,37 @@
+package queryf
+
+import "time"
+
+func (suite *QueryfTestSuite) TestTimeOptions() {
+	loc := time.FixedZone("BRT", -3*60*60)
+	t := time.Date(2022, 2, 10, 10, 45, 0, 123456789, loc)
+
+	suite.Equal(`SELECT '2022-02-10T10:45:00.123456789-03:00'`, Format(`SELECT $1`, t))
+	suite.Equal(`SELECT '2022-02-10T13:45:00.123456789Z'`, New(WithTimeLocation(time.UTC)).Format(`SELECT $1`, t))
+	suite.Equal(`SELECT '2022-02-10T10:45:00.123457-03:00'`, New(WithTimePrecision(time.Microsecond)).Format(`SELECT $1`, t))
+	suite.Equal(`SELECT '2022-02-10T10:45:00-03:00'`, New(WithTimePrecision(time.Second)).Format(`SELECT $1`, t))
+
+	f := New(WithTimeFormat("2006-01-02 15:04:05.000"), WithTimeLocation(time.UTC), WithTimePrecision(time.Millisecond))
+	suite.Equal(`SELECT '2022-02-10 13:45:00.123'`, f.Format(`SELECT $1`, t))
+	suite.Equal(`SELECT '{"2022-02-10 13:45:00.123"}'`, f.Format(`SELECT $1`, []time.Time{t}))
+}
+
+func (suite *QueryfTestSuite) TestDateOnly() {
+	midnight := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := time.Date(0, 1, 1, 13, 45, 0, 0, time.UTC)
+	noon := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	suite.Equal(`SELECT '2023-01-01T00:00:00Z'`, Format(`SELECT $1`, midnight))
+
+	f := New(WithDateOnly())
+	suite.Equal(`SELECT '2023-01-01'::date, '13:45:00'::time, '2023-01-01T12:00:00Z'`,
+		f.Format(`SELECT $1, $2, $3`, midnight, clock, noon))
+	suite.Equal(Date, f.NewArgument(midnight).GetType())
+	suite.Equal(TimeOfDay, f.NewArgument(clock).GetType())
+
+	// Midnight is checked after converting to the configured location
+	f = New(WithDateOnly(), WithTimeLocation(time.FixedZone("BRT", -3*60*60)))
+	suite.Equal(`SELECT '2022-12-31T21:00:00-03:00'`, f.Format(`SELECT $1`, midnight))
+
+	suite.Equal(`SELECT '2023-01-01'`, New(WithDateOnly(), WithDialect(MySQL)).Format(`SELECT ?`, midnight))
+}
@@ -0,0 +1,193 @@
+package queryf
+
+import "strings"
+
+type tokenKind int
+
+const (
+	// tokenText is any SQL outside of literals, comments and placeholders.
+	tokenText tokenKind = iota
+	// tokenString is a '...' or E'...' string literal.
+	tokenString
+	// tokenQuotedIdentifier is a "..." or `...` quoted identifier.
+	tokenQuotedIdentifier
+	// tokenComment is a -- line comment or a /* */ block comment.
+	tokenComment
+	// tokenDollarQuoted is a $tag$...$tag$ dollar-quoted string.
+	tokenDollarQuoted
+	// tokenPlaceholder is a placeholder in the tokenizer placeholder style.
+	tokenPlaceholder
+)
+
+type token struct {
+	kind tokenKind
+	// start and end are the byte offsets of the token in the query.
+	start, end int
+	// placeholder is only set for tokenPlaceholder tokens.
+	placeholder placeholder
+	// unterminated is set for literals and comments missing their closing
+	// delimiter, which extend to the end of the query.
+	unterminated bool
+	// escaped is set for the ?? text tokens of the Question style, which
+	// stand for a literal ?.
+	escaped bool
+}
+
+// tokenizer is a lightweight SQL tokenizer. It only knows enough SQL to tell
+// placeholders apart from string literals, quoted identifiers, comments and
+// dollar-quoted bodies, where placeholder-like text must be left untouched.
+// Unterminated literals and comments extend to the end of the query.
+type tokenizer struct {
+	query     string
+	style     PlaceholderStyle
+	backslash bool
+	pos       int
+	count     int
+}
+
+// syntax is what the tokenizer needs to know about the dialect of a query.
+type syntax struct {
+	// style is the placeholder style of the query.
+	style PlaceholderStyle
+	// backslash is set when '...' string literals have backslash escapes, as
+	// in MySQL, instead of only doubled quotes.
+	backslash bool
+}
+
+func newTokenizer(query string, s syntax) *tokenizer {
+	return &tokenizer{query: query, style: s.style, backslash: s.backslash}
+}
+
+// next returns the next token of the query, or false at the end of the query.
+func (t *tokenizer) next() (token, bool) {
+	if t.pos >= len(t.query) {
+		return token{}, false
+	}
+	start := t.pos
+	if tok, ok := t.special(start); ok {
+		t.pos = tok.end
+		return tok, true
+	}
+	for t.pos++; t.pos < len(t.query); t.pos++ {
+		if _, ok := t.peek(t.pos); ok {
+			break
+		}
+	}
+	return token{kind: tokenText, start: start, end: t.pos}, true
+}
+
+// peek reports whether a non-text token starts at position i, without
+// consuming it or counting positional placeholders.
+func (t *tokenizer) peek(i int) (token, bool) {
+	count := t.count
+	tok, ok := t.special(i)
+	t.count = count
+	return tok, ok
+}
+
+// special returns the literal, comment or placeholder token starting at
+// position i, if any.
+func (t *tokenizer) special(i int) (token, bool) {
+	q := t.query
+	switch c := q[i]; {
+	case c == '\'':
+		end, ok := t.quotedEnd(i+1, '\'', t.backslash)
+		return token{kind: tokenString, start: i, end: end, unterminated: !ok}, true
+	case (c == 'e' || c == 'E') && i+1 < len(q) && q[i+1] == '\'' && !t.followsName(i):
+		end, ok := t.quotedEnd(i+2, '\'', true)
+		return token{kind: tokenString, start: i, end: end, unterminated: !ok}, true
+	case c == '"' || c == '`':
+		end, ok := t.quotedEnd(i+1, c, false)
+		return token{kind: tokenQuotedIdentifier, start: i, end: end, unterminated: !ok}, true
+	case c == '-' && strings.HasPrefix(q[i:], "--"):
+		end := strings.IndexByte(q[i:], '\n')
+		if end == -1 {
+			return token{kind: tokenComment, start: i, end: len(q)}, true
+		}
+		return token{kind: tokenComment, start: i, end: i + end}, true
+	case c == '/' && strings.HasPrefix(q[i:], "/*"):
+		end, ok := t.blockCommentEnd(i)
+		return token{kind: tokenComment, start: i, end: end, unterminated: !ok}, true
+	case c == '$' && !t.followsName(i):
+		if end, terminated, ok := t.dollarQuotedEnd(i); ok {
+			return token{kind: tokenDollarQuoted, start: i, end: end, unterminated: !terminated}, true
+		}
+	}
+	if t.style == Question && strings.HasPrefix(q[i:], "??") {
+		return token{kind: tokenText, start: i, end: i + 2, escaped: true}, true
+	}
+	if p, ok := t.placeholderAt(i); ok {
+		return token{kind: tokenPlaceholder, start: p.start, end: p.end, placeholder: p}, true
+	}
+	return token{}, false
+}
+
+// followsName reports whether the character before position i is part of a
+// name, in which case it can't start a literal.
+func (t *tokenizer) followsName(i int) bool {
+	return i > 0 && (isNameChar(t.query[i-1]) || t.query[i-1] == '$')
+}
+
+// quotedEnd returns the end of a literal quoted by q whose body starts at i,
+// and false if it isn't terminated. Doubled quotes are part of the body, as
+// are backslash escapes when backslash is true.
+func (t *tokenizer) quotedEnd(i int, q byte, backslash bool) (int, bool) {
+	for ; i < len(t.query); i++ {
+		switch t.query[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case q:
+			if i+1 < len(t.query) && t.query[i+1] == q {
+				i++
+				continue
+			}
+			return i + 1, true
+		}
+	}
+	return len(t.query), false
+}
+
+// blockCommentEnd returns the end of the block comment starting at i, and
+// false if it isn't terminated. Block comments can be nested, as in Postgres.
+func (t *tokenizer) blockCommentEnd(i int) (int, bool) {
+	depth := 0
+	for i < len(t.query) {
+		switch {
+		case strings.HasPrefix(t.query[i:], "/*"):
+			depth++
+			i += 2
+		case strings.HasPrefix(t.query[i:], "*/"):
+			depth--
+			i += 2
+			if depth == 0 {
+				return i, true
+			}
+		default:
+			i++
+		}
+	}
+	return len(t.query), false
+}
+
+// dollarQuotedEnd returns the end of the dollar-quoted string starting at i,
+// and whether it is terminated. It returns false if there's no valid $tag$
+// opening at i.
+func (t *tokenizer) dollarQuotedEnd(i int) (end int, terminated, ok bool) {
+	end = i + 1
+	if end < len(t.query) && isNameStart(t.query[end]) {
+		for end < len(t.query) && isNameChar(t.query[end]) {
+			end++
+		}
+	}
+	if end >= len(t.query) || t.query[end] != '$' {
+		return 0, false, false
+	}
+	tag := t.query[i : end+1]
+	closing := strings.Index(t.query[end+1:], tag)
+	if closing == -1 {
+		return len(t.query), false, true
+	}
+	return end + 1 + closing + len(tag), true, true
+}
@@ -0,0 +1,34 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestSkipLiteralsAndComments() {
+	suite.Equal(`SELECT 1, '$1', 'it''s $1', E'\'$1', "$1"`,
+		Format(`SELECT $1, '$1', 'it''s $1', E'\'$1', "$1"`, 1))
+	suite.Equal("SELECT 1 -- $1\n, 1", Format("SELECT $1 -- $1\n, $1", 1))
+	suite.Equal(`SELECT /* $1 /* nested $1 */ $1 */ 1`, Format(`SELECT /* $1 /* nested $1 */ $1 */ $1`, 1))
+	suite.Equal(`SELECT $$ $1 $$, $fn$ $1 $fn$, 1`, Format(`SELECT $$ $1 $$, $fn$ $1 $fn$, $1`, 1))
+
+	// Unterminated literals extend to the end of the query
+	suite.Equal(`SELECT 1, '$1`, Format(`SELECT $1, '$1`, 1))
+
+	// Identifier characters right before a $ don't start a dollar-quoted string
+	suite.Equal(`SELECT a$b$ 1`, Format(`SELECT a$b$ $1`, 1))
+
+	f := New(WithPlaceholderStyle(Question))
+	suite.Equal(`SELECT 1, '?', 2`, f.Format(`SELECT ?, '?', ?`, 1, 2))
+	suite.Equal(`SELECT 1, ':id'`, FormatNamed(`SELECT :id, ':id'`, map[string]any{"id": 1}))
+}
+
+func (suite *QueryfTestSuite) TestTokenizer() {
+	query := `SELECT $1, 'a' /* c */ "b"`
+	t := newTokenizer(query, syntax{style: Dollar})
+	var kinds []tokenKind
+	var texts []string
+	for tok, ok := t.next(); ok; tok, ok = t.next() {
+		kinds = append(kinds, tok.kind)
+		texts = append(texts, query[tok.start:tok.end])
+	}
+	suite.Equal([]tokenKind{
+		tokenText, tokenPlaceholder, tokenText, tokenString, tokenText, tokenComment, tokenText, tokenQuotedIdentifier,
+	}, kinds)
+	suite.Equal([]string{`SELECT `, `$1`, `, `, `'a'`, ` `, `/* c */`, ` `, `"b"`}, texts)
+}
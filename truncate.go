@@ -0,0 +1,82 @@
+package queryf
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ellipsis marks the end of a truncated string.
+const ellipsis = "…"
+
+// WithMaxStringLen truncates string arguments longer than n bytes, e.g.
+// 'abc…'(+4096 bytes). The cut never splits a UTF-8 character.
+func WithMaxStringLen(n int) Option {
+	return func(f *Formatter) {
+		f.maxStringLen = n
+	}
+}
+
+// WithMaxBytesLen truncates []byte arguments longer than n bytes, e.g.
+// '\x0102'(+4096 bytes).
+func WithMaxBytesLen(n int) Option {
+	return func(f *Formatter) {
+		f.maxBytesLen = n
+	}
+}
+
+// WithMaxSliceElems truncates slices with more than n elements, e.g.
+// '{1,2,3}'(+9997 elements).
+func WithMaxSliceElems(n int) Option {
+	return func(f *Formatter) {
+		f.maxSliceElems = n
+	}
+}
+
+// truncateString returns s cut to the maximum string length and the marker
+// with the number of bytes left out, which is empty if s wasn't truncated.
+func (f *Formatter) truncateString(s string) (string, string) {
+	if f.maxStringLen <= 0 || len(s) <= f.maxStringLen {
+		return s, ""
+	}
+	end := f.maxStringLen
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end] + ellipsis, truncationMarker(len(s)-end, "bytes")
+}
+
+// truncateBytes returns b cut to the maximum bytes length and the marker with
+// the number of bytes left out, which is empty if b wasn't truncated.
+func (f *Formatter) truncateBytes(b []byte) ([]byte, string) {
+	if f.maxBytesLen <= 0 || len(b) <= f.maxBytesLen {
+		return b, ""
+	}
+	return b[:f.maxBytesLen], truncationMarker(len(b)-f.maxBytesLen, "bytes")
+}
+
+// truncateSlice returns how many of the n elements of a slice are formatted
+// and the marker with the number of elements left out, which is empty if the
+// slice wasn't truncated.
+func (f *Formatter) truncateSlice(n int) (int, string) {
+	if f.maxSliceElems <= 0 || n <= f.maxSliceElems {
+		return n, ""
+	}
+	return f.maxSliceElems, truncationMarker(n-f.maxSliceElems, "elements")
+}
+
+func truncationMarker(n int, unit string) string {
+	return fmt.Sprintf("(+%d %s)", n, unit)
+}
+
+// splitTruncationMarker splits a formatted literal from its truncation marker.
+func splitTruncationMarker(s string) (string, string) {
+	if !strings.HasSuffix(s, ")") {
+		return s, ""
+	}
+	i := strings.LastIndex(s, "(+")
+	if i == -1 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
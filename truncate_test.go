@@ -0,0 +1,26 @@
+package queryf
+
+import "strings"
+
+func (suite *QueryfTestSuite) TestMaxStringLen() {
+	f := New(WithMaxStringLen(3))
+	suite.Equal(`SELECT 'abc…'(+4096 bytes), 'ab'`, f.Format(`SELECT $1, $2`, "abc"+strings.Repeat("x", 4096), "ab"))
+	// Multi-byte characters are never split
+	suite.Equal(`SELECT 'aa…'(+2 bytes)`, f.Format(`SELECT $1`, "aaé"))
+	suite.Equal(`SELECT '{"abc…"(+1 bytes),"d"}'`, f.Format(`SELECT $1`, []string{"abcd", "d"}))
+}
+
+func (suite *QueryfTestSuite) TestMaxBytesLen() {
+	f := New(WithMaxBytesLen(2))
+	suite.Equal(`SELECT '\x0102'(+2 bytes), '\x01'`, f.Format(`SELECT $1, $2`, []byte{1, 2, 3, 4}, []byte{1}))
+}
+
+func (suite *QueryfTestSuite) TestMaxSliceElems() {
+	ints := make([]int, 10000)
+	for i := range ints {
+		ints[i] = i + 1
+	}
+	f := New(WithMaxSliceElems(3))
+	suite.Equal(`SELECT '{1,2,3}'(+9997 elements), '{1,2}'`, f.Format(`SELECT $1, $2`, ints, []int{1, 2}))
+	suite.Equal(`SELECT '{{1,2,3}(+1 elements)}'`, f.Format(`SELECT $1`, [][]int{{1, 2, 3, 4}}))
+}
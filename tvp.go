@@ -0,0 +1,74 @@
+package queryf
+
+import (
+	"reflect"
+	"strings"
+)
+
+// mssqlPkgPaths are the packages of go-mssqldb, whose TVP type is detected
+// without importing it.
+var mssqlPkgPaths = []string{"github.com/microsoft/go-mssqldb", "github.com/denisenkom/go-mssqldb"}
+
+// isTVP reports whether the argument is a go-mssqldb table-valued parameter.
+func (a *Argument) isTVP() bool {
+	t := a.getReflectedType()
+	for _, pkgPath := range mssqlPkgPaths {
+		if isNamedType(t, pkgPath, "TVP") {
+			return true
+		}
+	}
+	return false
+}
+
+// formatTVP returns the rows of a table-valued parameter, a slice of structs,
+// as a VALUES table named after the exported fields that go-mssqldb sends,
+// preceded by the table type in a comment:
+//
+//	/* dbo.UserType */ (VALUES (1, N'John'), (2, N'Jane')) AS tvp (ID, Name)
+func (a *Argument) formatTVP() string {
+	v := a.getReflectedValue()
+	comment := "/* " + v.FieldByName("TypeName").String() + " */ "
+	rows := v.FieldByName("Value")
+	for rows.Kind() == reflect.Interface || rows.Kind() == reflect.Ptr {
+		if rows.IsNil() {
+			return comment + a.formatNull()
+		}
+		rows = rows.Elem()
+	}
+	if rows.Kind() != reflect.Slice && rows.Kind() != reflect.Array {
+		return comment + a.nested(rows.Interface()).Format()
+	}
+	elem := rows.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return comment + a.nested(rows.Interface()).Format()
+	}
+	if rows.Len() == 0 {
+		return comment + a.formatNull()
+	}
+	var columns []int
+	var names []string
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Field(i)
+		if field.IsExported() && field.Tag.Get("tvp") != "-" {
+			columns = append(columns, i)
+			names = append(names, field.Name)
+		}
+	}
+	values := make([]string, 0, rows.Len())
+	for i := 0; i < rows.Len(); i++ {
+		row := rows.Index(i)
+		fields := make([]string, len(columns))
+		for j, column := range columns {
+			if row.Kind() == reflect.Ptr && row.IsNil() {
+				fields[j] = a.formatNull()
+				continue
+			}
+			fields[j] = a.nested(reflect.Indirect(row).Field(column).Interface()).Format()
+		}
+		values = append(values, "("+strings.Join(fields, ", ")+")")
+	}
+	return comment + "(VALUES " + strings.Join(values, ", ") + ") AS tvp (" + strings.Join(names, ", ") + ")"
+}
@@ -0,0 +1,28 @@
+package queryf
+
+// tvp has the fields of a go-mssqldb TVP.
+type tvp struct {
+	TypeName string
+	Value    any
+}
+
+type tvpUser struct {
+	ID       int
+	Name     string
+	Password string `tvp:"-"`
+	note     string
+}
+
+func (suite *QueryfTestSuite) TestFormatTVP() {
+	f := New(WithDialect(SQLServer))
+	format := func(arg tvp) string {
+		return (&Argument{arg: arg, formatter: f}).formatTVP()
+	}
+	users := []tvpUser{{ID: 1, Name: "John", Password: "x"}, {ID: 2, Name: "Jane", note: "y"}}
+	suite.Equal(`/* dbo.UserType */ (VALUES (1, N'John'), (2, N'Jane')) AS tvp (ID, Name)`, format(tvp{"dbo.UserType", users}))
+	suite.Equal(`/* dbo.UserType */ (VALUES (1, N'John'), (NULL, NULL)) AS tvp (ID, Name)`, format(tvp{"dbo.UserType", []*tvpUser{&users[0], nil}}))
+	suite.Equal(`/* dbo.UserType */ NULL`, format(tvp{"dbo.UserType", []tvpUser{}}))
+	suite.Equal(`/* dbo.UserType */ NULL`, format(tvp{"dbo.UserType", nil}))
+	suite.Equal(`/* dbo.IDs */ N'{1,2}'`, format(tvp{"dbo.IDs", []int{1, 2}}))
+	suite.False((&Argument{arg: tvp{}, formatter: f}).isTVP())
+}
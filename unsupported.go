@@ -0,0 +1,121 @@
+package queryf
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ancestor identifies a pointer, map or slice a nested argument belongs to.
+// Arguments keep these instead of a pointer to their parent, which would move
+// every argument to the heap.
+type ancestor struct {
+	identity
+	parent *ancestor
+}
+
+// contains reports whether id is the identity of p or of one of its parents.
+func (p *ancestor) contains(id identity) bool {
+	for ; p != nil; p = p.parent {
+		if p.identity == id {
+			return true
+		}
+	}
+	return false
+}
+
+type identity struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+// identity returns the type, address and length of the argument, or false if
+// it isn't a non nil pointer, map or slice.
+func (a *Argument) identity() (identity, bool) {
+	v := a.getReflectedValue()
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map:
+		if !v.IsNil() {
+			return identity{typ: v.Type(), ptr: v.Pointer()}, true
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			return identity{typ: v.Type(), ptr: v.Pointer(), len: v.Len()}, true
+		}
+	}
+	return identity{}, false
+}
+
+// ancestry returns the ancestors of the values nested in the argument. Slices
+// of basic types are left out, since their elements can't refer back to them.
+func (a *Argument) ancestry() *ancestor {
+	if t := a.getReflectedType(); t != nil && t.Kind() == reflect.Slice && isBasicKind(t.Elem().Kind()) {
+		return a.ancestors
+	}
+	if id, ok := a.identity(); ok {
+		return &ancestor{identity: id, parent: a.ancestors}
+	}
+	return a.ancestors
+}
+
+// nested returns the Argument of a value nested in a, such as an element of a
+// slice or the target of a pointer, so cycles back to a can be detected.
+func (a *Argument) nested(arg any) *Argument {
+	return &Argument{arg: arg, formatter: a.formatter, ancestors: a.ancestry(), depth: a.depth + 1, inner: true}
+}
+
+// unwrap returns the Argument of the value wrapped by a, such as the target of
+// a pointer or the elements of a pgtype.Array. Unlike nested values, wrapped
+// values are at the same depth, as pointers are in JSON.
+func (a *Argument) unwrap(arg any) *Argument {
+	return &Argument{arg: arg, formatter: a.formatter, ancestors: a.ancestry(), depth: a.depth, inner: true}
+}
+
+// checkNesting returns the marker, before quoting, that replaces a nested
+// argument when it refers back to one of its parents or is nested too deep, or
+// false if it can be formatted.
+func (a *Argument) checkNesting() (string, bool) {
+	if a.ancestors == nil && a.depth == 0 {
+		return "", false
+	}
+	if a.depth > a.formatter.depthLimit() {
+		return depthMarker, true
+	}
+	if a.isCycle() {
+		return a.cycleMarker(), true
+	}
+	return "", false
+}
+
+// isCycle reports whether the argument is the same pointer, map or slice as
+// one of its parents.
+func (a *Argument) isCycle() bool {
+	id, ok := a.identity()
+	if !ok {
+		return false
+	}
+	return a.ancestors.contains(id)
+}
+
+func (a *Argument) cycleMarker() string {
+	return fmt.Sprintf("<cycle %T>", a.arg)
+}
+
+// isUnsupported reports whether the argument has no SQL literal, like
+// channels, functions, unsafe pointers and complex numbers.
+func (a *Argument) isUnsupported() bool {
+	switch a.getReflectedType().Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+func (a *Argument) formatUnsupported() string {
+	return a.formatter.quote(fmt.Sprintf("<unsupported %T>", a.arg))
+}
+
+// isBasicKind reports whether values of the kind hold no references.
+func isBasicKind(kind reflect.Kind) bool {
+	return kind > reflect.Invalid && kind <= reflect.Complex128 || kind == reflect.String
+}
@@ -0,0 +1,52 @@
+package queryf
+
+import (
+	"database/sql/driver"
+	"unsafe"
+)
+
+// loopValuer returns itself from Value, so it never reaches a driver value.
+type loopValuer struct{}
+
+func (loopValuer) Value() (driver.Value, error) {
+	return loopValuer{}, nil
+}
+
+type treeNode struct {
+	Name   string
+	Parent *treeNode
+}
+
+func (suite *QueryfTestSuite) TestFormatUnsupported() {
+	n := 1
+	suite.Equal(`SELECT '<unsupported chan int>'`, Format(`SELECT $1`, make(chan int)))
+	suite.Equal(`SELECT '<unsupported func()>'`, Format(`SELECT $1`, func() {}))
+	suite.Equal(`SELECT '<unsupported unsafe.Pointer>'`, Format(`SELECT $1`, unsafe.Pointer(&n)))
+	suite.Equal(`SELECT '<unsupported complex128>'`, Format(`SELECT $1`, 1+2i))
+	suite.Equal(`SELECT '{"<unsupported chan int>"}'`, Format(`SELECT $1`, []chan int{make(chan int)}))
+	suite.Equal(`SELECT '{"C":"<unsupported chan int>"}'`, Format(`SELECT $1`, struct{ C chan int }{}))
+	suite.Equal(Unsupported, NewArgument(make(chan int)).GetType())
+}
+
+func (suite *QueryfTestSuite) TestFormatCycle() {
+	s := []any{1, nil}
+	s[1] = s
+	suite.Equal(`SELECT '{1,"<cycle []interface {}>"}'`, Format(`SELECT $1`, s))
+
+	m := map[string]any{}
+	m["self"] = m
+	suite.Equal(`SELECT '{"self":"<cycle map[string]interface {}>"}'`, Format(`SELECT $1`, m))
+
+	var p any
+	p = &p
+	suite.Equal(`SELECT '<cycle *interface {}>'`, Format(`SELECT $1`, p))
+
+	node := &treeNode{Name: "root"}
+	node.Parent = node
+	suite.Equal(`SELECT '{"Name":"root","Parent":"<cycle *queryf.treeNode>"}'`, Format(`SELECT $1`, node))
+
+	shared := []int{1}
+	suite.Equal(`SELECT '{{1},{1}}'`, Format(`SELECT $1`, [][]int{shared, shared}))
+
+	suite.Equal(`SELECT '<max depth>'`, Format(`SELECT $1`, loopValuer{}))
+}
@@ -0,0 +1,12 @@
+package queryf
+
+// uuid mimics the UUID types of google/uuid and gofrs/uuid.
+type uuid [16]byte
+
+func (suite *QueryfTestSuite) TestUUID() {
+	u := uuid{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	suite.Equal(`SELECT '123e4567-e89b-12d3-a456-426614174000'`, Format(`SELECT $1`, u))
+	suite.Equal(`SELECT '123e4567-e89b-12d3-a456-426614174000'`, Format(`SELECT $1`, [16]byte(u)))
+	suite.Equal(`SELECT '123e4567-e89b-12d3-a456-426614174000'`, Format(`SELECT $1`, &u))
+	suite.Equal(UUID, NewArgument(u).GetType())
+}
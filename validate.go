@@ -0,0 +1,183 @@
+package queryf
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrArgumentCount is returned when the number of placeholders in the
+	// query doesn't match the number of arguments.
+	ErrArgumentCount = errors.New("queryf: number of placeholders doesn't match number of arguments")
+	// ErrMissingArgument is returned when a placeholder refers to an argument
+	// that wasn't given.
+	ErrMissingArgument = errors.New("queryf: placeholder has no matching argument")
+	// ErrUnsupportedType is returned when an argument can't be formatted.
+	ErrUnsupportedType = errors.New("queryf: unsupported argument type")
+	// ErrPlaceholderGap is returned by Validate when the placeholder numbers
+	// skip an argument, e.g. $1 and $3 without $2.
+	ErrPlaceholderGap = errors.New("queryf: gap in placeholder numbering")
+	// ErrDuplicateName is returned by Validate when two sql.NamedArg arguments
+	// have the same name.
+	ErrDuplicateName = errors.New("queryf: duplicate argument name")
+	// ErrUnexpectedNull is returned by Validate when a typed nil argument, e.g.
+	// a nil pointer or slice, would be formatted as NULL.
+	ErrUnexpectedNull = errors.New("queryf: typed nil argument is NULL")
+	// ErrUnterminated is returned by Placeholders when a string literal, quoted
+	// identifier or comment isn't closed.
+	ErrUnterminated = errors.New("queryf: unterminated literal or comment")
+)
+
+// FormatE works like Format but returns an error instead of silently leaving
+// placeholders or arguments unused.
+//
+// Example:
+//
+//	_, err := FormatE("SELECT * FROM users WHERE id = $1 AND name = $2", 1)
+//	fmt.Println(err)
+//	// Output: queryf: placeholder has no matching argument: $2 (1 arguments given)
+func FormatE(query string, args ...any) (string, error) {
+	return Default().FormatE(query, args...)
+}
+
+// FormatE works like Format but returns an error instead of silently leaving
+// placeholders or arguments unused. Like Format, it applies the options passed
+// among the arguments to this call only. See the package level FormatE for
+// details.
+func (f *Formatter) FormatE(query string, args ...any) (string, error) {
+	if opts, rest := callOptions(args); opts != nil {
+		return f.With(opts...).FormatE(query, rest...)
+	}
+	if f.locked() {
+		return "", ErrLocked
+	}
+	query, args = rewriteQuery(query, args)
+	if err := f.checkPlaceholders(query, args); err != nil {
+		return "", err
+	}
+	for i, arg := range args {
+		if t := unsupportedType(reflect.TypeOf(arg)); t != nil {
+			return "", fmt.Errorf("%w: %s at position %d", ErrUnsupportedType, t, i+1)
+		}
+	}
+	return f.Format(query, args...), nil
+}
+
+func (f *Formatter) checkPlaceholders(query string, args []any) error {
+	s := newScanner(query, f.syntax())
+	if f.style() == Question {
+		for _, ok := s.next(); ok; _, ok = s.next() {
+		}
+		if s.count() != len(args) {
+			return fmt.Errorf("%w: %d placeholders, %d arguments", ErrArgumentCount, s.count(), len(args))
+		}
+		return nil
+	}
+	seen := map[int]bool{}
+	for p, ok := s.next(); ok; p, ok = s.next() {
+		if p.index < 1 || p.index > len(args) {
+			return fmt.Errorf("%w: %s (%d arguments given)", ErrMissingArgument, query[p.start:p.end], len(args))
+		}
+		seen[p.index] = true
+	}
+	if len(seen) != len(args) {
+		return fmt.Errorf("%w: %d placeholders, %d arguments", ErrArgumentCount, len(seen), len(args))
+	}
+	return nil
+}
+
+// Validate reports every problem found in the query and its arguments, joined
+// with errors.Join: placeholders without arguments, unused arguments, gaps in
+// the placeholder numbering, duplicate sql.NamedArg names, typed nil arguments
+// and unsupported types. It is meant to be used in tests.
+//
+// Example:
+//
+//	err := queryf.Validate("SELECT * FROM users WHERE id = $1 AND name = $3", 1, "John", (*int)(nil))
+//	fmt.Println(err)
+//	// Output:
+//	// queryf: gap in placeholder numbering: $2
+//	// queryf: typed nil argument is NULL: *int at position 3
+func Validate(query string, args ...any) error {
+	return Default().Validate(query, args...)
+}
+
+// Validate reports every problem found in the query and its arguments, using
+// the Formatter placeholder style. See the package level Validate for details.
+func (f *Formatter) Validate(query string, args ...any) error {
+	query, args = rewriteQuery(query, args)
+	var errs []error
+	s := newScanner(query, f.syntax())
+	seen := map[int]bool{}
+	for p, ok := s.next(); ok; p, ok = s.next() {
+		if f.style() != Question && (p.index < 1 || p.index > len(args)) {
+			errs = append(errs, fmt.Errorf("%w: %s (%d arguments given)", ErrMissingArgument, query[p.start:p.end], len(args)))
+		}
+		seen[p.index] = true
+	}
+	if f.style() == Question {
+		if s.count() != len(args) {
+			errs = append(errs, fmt.Errorf("%w: %d placeholders, %d arguments", ErrArgumentCount, s.count(), len(args)))
+		}
+	} else {
+		errs = append(errs, placeholderGaps(f.style(), seen, len(args))...)
+	}
+
+	names := map[string]bool{}
+	for i, arg := range args {
+		if named, ok := arg.(sql.NamedArg); ok {
+			if names[named.Name] {
+				errs = append(errs, fmt.Errorf("%w: %s at position %d", ErrDuplicateName, named.Name, i+1))
+			}
+			names[named.Name] = true
+			arg = named.Value
+		}
+		if t := unsupportedType(reflect.TypeOf(arg)); t != nil {
+			errs = append(errs, fmt.Errorf("%w: %s at position %d", ErrUnsupportedType, t, i+1))
+		} else if arg != nil && f.NewArgument(arg).isNull() {
+			errs = append(errs, fmt.Errorf("%w: %T at position %d", ErrUnexpectedNull, arg, i+1))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// placeholderGaps returns an error for the missing numbers below the highest
+// placeholder seen, and for the arguments after it, which no placeholder uses.
+func placeholderGaps(style PlaceholderStyle, seen map[int]bool, args int) []error {
+	highest := 0
+	for index := range seen {
+		highest = max(highest, index)
+	}
+	var gaps []string
+	for index := 1; index < highest; index++ {
+		if !seen[index] {
+			gaps = append(gaps, placeholderText(style, index))
+		}
+	}
+	var errs []error
+	if len(gaps) > 0 {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrPlaceholderGap, strings.Join(gaps, ", ")))
+	}
+	if highest < args {
+		errs = append(errs, fmt.Errorf("%w: %d placeholders, %d arguments", ErrArgumentCount, highest, args))
+	}
+	return errs
+}
+
+// unsupportedType returns the first type reachable from t that can't be
+// formatted, or nil if every type is supported.
+func unsupportedType(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return t
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return unsupportedType(t.Elem())
+	}
+	return nil
+}
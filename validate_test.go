@@ -0,0 +1,67 @@
+package queryf
+
+import "database/sql"
+
+func (suite *QueryfTestSuite) TestFormatE() {
+	result, err := FormatE(`SELECT $1, $2, $1`, 4, 5)
+	suite.Nil(err)
+	suite.Equal(`SELECT 4, 5, 4`, result)
+
+	_, err = FormatE(`SELECT $1, $3`, 4, 5)
+	suite.ErrorIs(err, ErrMissingArgument)
+	suite.EqualError(err, `queryf: placeholder has no matching argument: $3 (2 arguments given)`)
+
+	_, err = FormatE(`SELECT $0`, 4)
+	suite.ErrorIs(err, ErrMissingArgument)
+
+	_, err = FormatE(`SELECT $1`, 4, 5)
+	suite.ErrorIs(err, ErrArgumentCount)
+
+	_, err = FormatE(`SELECT $1`, make(chan int))
+	suite.ErrorIs(err, ErrUnsupportedType)
+	suite.EqualError(err, `queryf: unsupported argument type: chan int at position 1`)
+
+	_, err = FormatE(`SELECT $1`, []func(){})
+	suite.ErrorIs(err, ErrUnsupportedType)
+}
+
+func (suite *QueryfTestSuite) TestFormatEQuestion() {
+	f := New(WithPlaceholderStyle(Question))
+	result, err := f.FormatE(`SELECT ?, ?`, 1, 2)
+	suite.Nil(err)
+	suite.Equal(`SELECT 1, 2`, result)
+
+	_, err = f.FormatE(`SELECT ?, ?`, 1)
+	suite.ErrorIs(err, ErrArgumentCount)
+}
+
+func (suite *QueryfTestSuite) TestValidate() {
+	suite.Nil(Validate(`SELECT $1, $2, $1`, 1, "a"))
+	suite.Nil(Validate(`SELECT $1`, nil))
+
+	err := Validate(`SELECT $1, $3, $5, '$2'`, 1, 2, 3, 4, 5, 6)
+	suite.ErrorIs(err, ErrPlaceholderGap)
+	suite.ErrorIs(err, ErrArgumentCount)
+	suite.EqualError(err, "queryf: gap in placeholder numbering: $2, $4\nqueryf: number of placeholders doesn't match number of arguments: 5 placeholders, 6 arguments")
+
+	var ptr *int
+	var ints []int
+	err = Validate(`SELECT $1, $2, $3, $4, $5`, sql.Named("a", 1), sql.Named("a", 2), ptr, ints, make(chan int))
+	suite.ErrorIs(err, ErrDuplicateName)
+	suite.ErrorIs(err, ErrUnexpectedNull)
+	suite.ErrorIs(err, ErrUnsupportedType)
+	suite.EqualError(err, "queryf: duplicate argument name: a at position 2\n"+
+		"queryf: typed nil argument is NULL: *int at position 3\n"+
+		"queryf: typed nil argument is NULL: []int at position 4\n"+
+		"queryf: unsupported argument type: chan int at position 5")
+
+	err = Validate(`SELECT $1, $3`, 1)
+	suite.ErrorIs(err, ErrMissingArgument)
+	suite.ErrorIs(err, ErrPlaceholderGap)
+
+	f := New(WithPlaceholderStyle(Question))
+	suite.Nil(f.Validate(`SELECT ?, ?`, 1, 2))
+	suite.ErrorIs(f.Validate(`SELECT ?`, 1, 2), ErrArgumentCount)
+	suite.EqualError(New(WithPlaceholderStyle(Colon)).Validate(`SELECT :2`, 1, 2), "queryf: gap in placeholder numbering: :1")
+	suite.Nil(New(WithNilSliceAsEmpty()).Validate(`SELECT $1`, ints))
+}
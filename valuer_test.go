@@ -0,0 +1,40 @@
+package queryf
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// nullString mimics guregu/null's null.String, which embeds sql.NullString.
+type nullString struct {
+	sql.NullString
+}
+
+// nullInt mimics volatiletech/null's null.Int, which implements its own Value.
+type nullInt struct {
+	Int   int
+	Valid bool
+}
+
+func (n nullInt) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return int64(n.Int), nil
+}
+
+type brokenValuer struct{}
+
+func (brokenValuer) Value() (driver.Value, error) {
+	return nil, errors.New("boom")
+}
+
+func (suite *QueryfTestSuite) TestValuer() {
+	suite.Equal(`SELECT 'John', NULL`, Format(`SELECT $1, $2`,
+		nullString{sql.NullString{String: "John", Valid: true}}, nullString{}))
+	suite.Equal(`SELECT 5, NULL`, Format(`SELECT $1, $2`, nullInt{Int: 5, Valid: true}, nullInt{}))
+	suite.Equal(`SELECT 5, NULL`, Format(`SELECT $1, $2`, sql.NullInt64{Int64: 5, Valid: true}, &sql.NullInt64{}))
+	suite.Equal(`SELECT '<invalid value: boom>'`, Format(`SELECT $1`, brokenValuer{}))
+	suite.Equal(Valuer, NewArgument(nullInt{}).GetType())
+}
@@ -0,0 +1,67 @@
+package queryf
+
+import "strings"
+
+// FormatValues returns a VALUES clause with a row of literals per element of
+// rows, to debug or reproduce bulk inserts. The arguments of each row are
+// formatted like the arguments of Format, and their 1-based column is the
+// index passed to the redactors.
+//
+// Example:
+//
+//	fmt.Println(queryf.FormatValues([][]any{{1, "John"}, {2, nil}}))
+//	// Output: VALUES (1, 'John'), (2, NULL)
+func FormatValues(rows [][]any) string {
+	return Default().FormatValues(rows)
+}
+
+// FormatValues returns a VALUES clause with the rows formatted with the
+// Formatter configuration. See the package level FormatValues for details.
+func (f *Formatter) FormatValues(rows [][]any) string {
+	var b strings.Builder
+	b.WriteString("VALUES")
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(" (")
+		for j, arg := range row {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(f.formatArg(j+1, arg))
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// BuildInsert returns a multi-row INSERT of the rows into the columns of the
+// table, with the VALUES clause of FormatValues. The table and column names are
+// written as given.
+//
+// Example:
+//
+//	fmt.Println(queryf.BuildInsert("users", []string{"id", "name"}, [][]any{{1, "John"}, {2, "Jane"}}))
+//	// Output: INSERT INTO users (id, name) VALUES (1, 'John'), (2, 'Jane')
+//
+//	** Passing this resulting string to a database may lead to SQL injections. **
+func BuildInsert(table string, cols []string, rows [][]any) string {
+	return Default().BuildInsert(table, cols, rows)
+}
+
+// BuildInsert returns a multi-row INSERT with the rows formatted with the
+// Formatter configuration. See the package level BuildInsert for details.
+func (f *Formatter) BuildInsert(table string, cols []string, rows [][]any) string {
+	var b strings.Builder
+	b.WriteString("INSERT INTO ")
+	b.WriteString(table)
+	if len(cols) > 0 {
+		b.WriteString(" (")
+		b.WriteString(strings.Join(cols, ", "))
+		b.WriteByte(')')
+	}
+	b.WriteByte(' ')
+	b.WriteString(f.FormatValues(rows))
+	return b.String()
+}
@@ -0,0 +1,20 @@
+package queryf
+
+func (suite *QueryfTestSuite) TestFormatValues() {
+	suite.Equal(`VALUES (1, 'John'), (2, NULL)`, FormatValues([][]any{{1, "John"}, {2, nil}}))
+	suite.Equal(`VALUES (1)`, FormatValues([][]any{{1}}))
+	suite.Equal(`VALUES ()`, FormatValues([][]any{{}}))
+
+	f := New(WithDialect(MySQL), WithRedactedParams(2))
+	suite.Equal(`VALUES (1, '[REDACTED]'), (0, '[REDACTED]')`, f.FormatValues([][]any{{true, "secret"}, {false, "other"}}))
+}
+
+func (suite *QueryfTestSuite) TestBuildInsert() {
+	suite.Equal(`INSERT INTO users (id, name) VALUES (1, 'John'), (2, 'Jane')`,
+		BuildInsert("users", []string{"id", "name"}, [][]any{{1, "John"}, {2, "Jane"}}))
+	suite.Equal(`INSERT INTO users VALUES (1, '{"admin"}')`,
+		BuildInsert("users", nil, [][]any{{1, []string{"admin"}}}))
+
+	f := New(WithNullLiteral("null"))
+	suite.Equal(`INSERT INTO public.users (id) VALUES (null)`, f.BuildInsert("public.users", []string{"id"}, [][]any{{nil}}))
+}
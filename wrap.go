@@ -0,0 +1,138 @@
+package queryf
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+)
+
+// defaultSlowThreshold is the duration above which WrapDB logs queries, unless
+// WithSlowThreshold is given.
+const defaultSlowThreshold = 200 * time.Millisecond
+
+// SlowDB is a *sql.DB that logs the queries slower than a threshold, formatted.
+// Use WrapDB to create one.
+type SlowDB struct {
+	*sql.DB
+	threshold time.Duration
+	logger    *slog.Logger
+	formatter *Formatter
+	recorder  *Recorder
+	collector Collector
+}
+
+// DBOption configures a SlowDB.
+type DBOption func(*SlowDB)
+
+// WithSlowThreshold logs the queries that take at least d. Defaults to 200ms,
+// and 0 logs every query.
+func WithSlowThreshold(d time.Duration) DBOption {
+	return func(db *SlowDB) {
+		db.threshold = d
+	}
+}
+
+// WithLogger sets the logger of the slow queries. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) DBOption {
+	return func(db *SlowDB) {
+		db.logger = logger
+	}
+}
+
+// WithFormatter sets the Formatter used to format the slow and recorded
+// queries. Defaults to the package level configuration.
+func WithFormatter(f *Formatter) DBOption {
+	return func(db *SlowDB) {
+		db.formatter = f
+	}
+}
+
+// WrapDB returns db wrapped so the queries that take longer than the threshold
+// are logged formatted, at the warn level, or at the error level if they
+// failed. Every *sql.DB method is available, but only the Query, QueryRow and
+// Exec methods are timed.
+//
+// Example:
+//
+//	db := queryf.WrapDB(db, queryf.WithSlowThreshold(200*time.Millisecond), queryf.WithLogger(logger))
+//	rows, err := db.QueryContext(ctx, "SELECT * FROM users WHERE id = $1", 1)
+//	// WARN slow query query="SELECT * FROM users WHERE id = 1" duration=1.5s
+func WrapDB(db *sql.DB, opts ...DBOption) *SlowDB {
+	s := &SlowDB{DB: db, threshold: defaultSlowThreshold}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.logger == nil {
+		s.logger = slog.Default()
+	}
+	return s
+}
+
+// QueryContext runs the query like sql.DB.QueryContext, logging it if slow.
+func (db *SlowDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	db.log(ctx, query, args, time.Since(start), err)
+	return rows, err
+}
+
+// QueryRowContext runs the query like sql.DB.QueryRowContext, logging it if
+// slow.
+func (db *SlowDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	db.log(ctx, query, args, time.Since(start), row.Err())
+	return row
+}
+
+// ExecContext runs the query like sql.DB.ExecContext, logging it if slow.
+func (db *SlowDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := db.DB.ExecContext(ctx, query, args...)
+	db.log(ctx, query, args, time.Since(start), err)
+	return result, err
+}
+
+// Query runs the query like sql.DB.Query, logging it if slow.
+func (db *SlowDB) Query(query string, args ...any) (*sql.Rows, error) {
+	return db.QueryContext(context.Background(), query, args...)
+}
+
+// QueryRow runs the query like sql.DB.QueryRow, logging it if slow.
+func (db *SlowDB) QueryRow(query string, args ...any) *sql.Row {
+	return db.QueryRowContext(context.Background(), query, args...)
+}
+
+// Exec runs the query like sql.DB.Exec, logging it if slow.
+func (db *SlowDB) Exec(query string, args ...any) (sql.Result, error) {
+	return db.ExecContext(context.Background(), query, args...)
+}
+
+func (db *SlowDB) log(ctx context.Context, query string, args []any, duration time.Duration, err error) {
+	f := db.formatter
+	if f == nil {
+		f = Default()
+	}
+	if db.recorder != nil {
+		db.recorder.record(f, query, args, duration, err)
+	}
+	if db.collector != nil {
+		db.collector.Observe(f.Fingerprint(query), duration, err)
+	}
+	if duration < db.threshold {
+		return
+	}
+	level := slog.LevelWarn
+	if err != nil {
+		level = slog.LevelError
+	}
+	if !db.logger.Enabled(ctx, level) {
+		return
+	}
+	attrs := []slog.Attr{slog.String("query", f.Format(query, args...)), slog.Duration("duration", duration)}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	db.logger.LogAttrs(ctx, level, "slow query", attrs...)
+}
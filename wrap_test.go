@@ -0,0 +1,50 @@
+package queryf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+func (suite *QueryfTestSuite) TestWrapDB() {
+	fake, sqlDB := newFakeDB("a")
+	defer sqlDB.Close()
+	var buf bytes.Buffer
+
+	db := WrapDB(sqlDB, WithSlowThreshold(0), WithLogger(newTestLogger(&buf)), WithFormatter(New(WithRedactedParams(2))))
+	var name string
+	suite.Nil(db.QueryRow(`SELECT name FROM t WHERE id = $1 AND token = $2`, 1, "secret").Scan(&name))
+	suite.Equal("a", name)
+	_, err := db.Exec(`DELETE FROM t WHERE id = $1`, 2)
+	suite.Nil(err)
+	rows, err := db.Query(`SELECT 1`)
+	suite.Nil(err)
+	suite.Nil(rows.Close())
+	suite.Len(fake.statements, 3)
+	suite.Equal("level=WARN msg=\"slow query\" query=\"SELECT name FROM t WHERE id = 1 AND token = '[REDACTED]'\"\n"+
+		"level=WARN msg=\"slow query\" query=\"DELETE FROM t WHERE id = 2\"\n"+
+		"level=WARN msg=\"slow query\" query=\"SELECT 1\"\n", buf.String())
+
+	buf.Reset()
+	fake.err = errors.New("boom")
+	_, err = db.ExecContext(context.Background(), `DELETE FROM t`)
+	suite.EqualError(err, "boom")
+	suite.Equal("level=ERROR msg=\"slow query\" query=\"DELETE FROM t\" error=boom\n", buf.String())
+}
+
+func (suite *QueryfTestSuite) TestWrapDBThreshold() {
+	_, sqlDB := newFakeDB()
+	defer sqlDB.Close()
+	var buf bytes.Buffer
+
+	db := WrapDB(sqlDB, WithSlowThreshold(time.Hour), WithLogger(newTestLogger(&buf)))
+	_, err := db.ExecContext(context.Background(), `DELETE FROM t`)
+	suite.Nil(err)
+	suite.Empty(buf.String())
+
+	suite.Equal(defaultSlowThreshold, WrapDB(sqlDB).threshold)
+	suite.Equal(slog.Default(), WrapDB(sqlDB).logger)
+	var _ DB = db
+}